@@ -3,6 +3,6 @@ package adt
 type PriorityQueue[T any] interface {
 	Len() int
 	Push(T)
-	Pop()
+	Pop() T
 	Top() T
 }
@@ -1,29 +1,70 @@
 package avl_tree
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrLengthMismatch = errors.New("avl_tree: keys and values have different lengths")
+	ErrUnsorted       = errors.New("avl_tree: keys are not sorted")
+	ErrDuplicateKey   = errors.New("avl_tree: duplicate key")
+)
+
 type node[K any, V any] struct {
 	left   *node[K, V]
 	right  *node[K, V]
 	key    K
 	value  V
 	height int
+	size   int
+	agg    *V // the aggregate of the subtree, nil if the tree is not augmented
+}
+
+// A node allocated together with its aggregate.
+type augmentedNode[K any, V any] struct {
+	node[K, V]
+	agg V
+}
+
+// Return the height of the subtree rooted at n, -1 if n is nil.
+func (n *node[K, V]) treeHeight() int {
+	if n == nil {
+		return -1
+	}
+	return n.height
 }
 
 // Get the height of the left and right child nodes.
 func (n *node[K, V]) childHeights() (int, int) {
-	l, r := -1, -1
-	if n.left != nil {
-		l = n.left.height
-	}
-	if n.right != nil {
-		r = n.right.height
+	return n.left.treeHeight(), n.right.treeHeight()
+}
+
+// Return the number of nodes in the subtree rooted at n.
+func (n *node[K, V]) count() int {
+	if n == nil {
+		return 0
 	}
-	return l, r
+	return n.size
 }
 
-// Update the node height based on child nodes' height.
-func (n *node[K, V]) updateHeight() {
+// Update the node height and size based on child nodes.
+func (n *node[K, V]) update() {
 	l, r := n.childHeights()
 	n.height = max(l, r) + 1
+	n.size = n.left.count() + n.right.count() + 1
+}
+
+// Return the key value pair and the exist indicator.
+//
+// If n is nil, it returns (zero value, zero value, false).
+func (n *node[K, V]) entry() (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
 }
 
 // Return the node balance factor.
@@ -35,15 +76,58 @@ func (n *node[K, V]) balanceFactor() int {
 }
 
 type AVLTree[K any, V any] struct {
-	root *node[K, V]
-	len  int
-	cmp  func(K, K) int
+	root     *node[K, V]
+	len      int
+	cmp      func(K, K) int
+	combine  func(V, V) V
+	identity V
+	mod      uint // number of structural modifications, to detect invalid iterators
 }
 
 func New[K any, V any](predicate func(K, K) int) AVLTree[K, V] {
 	return AVLTree[K, V]{cmp: predicate}
 }
 
+// Create a tree that caches the aggregate of the values in every subtree.
+//
+// combine must be associative with identity as its identity element.
+// The values are combined in ascending key order, so combine needs not be commutative.
+func NewAugmented[K any, V any](predicate func(K, K) int, combine func(V, V) V, identity V) AVLTree[K, V] {
+	return AVLTree[K, V]{cmp: predicate, combine: combine, identity: identity}
+}
+
+// Build a perfectly balanced tree from keys sorted in ascending order by predicate, in Θ(n).
+//
+// It returns an error if the lengths differ, the keys are not sorted, or a key is duplicated.
+// The nodes are allocated in a single slab, see FromSortedTrusted.
+func FromSorted[K any, V any](keys []K, values []V, predicate func(K, K) int) (AVLTree[K, V], error) {
+	if len(keys) != len(values) {
+		return New[K, V](predicate), ErrLengthMismatch
+	}
+
+	for i := 1; i < len(keys); i++ {
+		if cmp := predicate(keys[i-1], keys[i]); cmp == 0 {
+			return New[K, V](predicate), fmt.Errorf("%w at index %d", ErrDuplicateKey, i)
+		} else if cmp > 0 {
+			return New[K, V](predicate), fmt.Errorf("%w at index %d", ErrUnsorted, i)
+		}
+	}
+	return FromSortedTrusted(keys, values, predicate), nil
+}
+
+// Build a perfectly balanced tree from keys sorted in ascending order by predicate, in Θ(n).
+//
+// Unlike FromSorted, it trusts the input without validation.
+// The keys must be sorted without duplication, and have the same length as values.
+//
+// The nodes are allocated in a single slab, which is not freed until every node is removed.
+func FromSortedTrusted[K any, V any](keys []K, values []V, predicate func(K, K) int) AVLTree[K, V] {
+	tree := New[K, V](predicate)
+	tree.root = tree.build(make([]node[K, V], len(keys)), keys, values)
+	tree.len = len(keys)
+	return tree
+}
+
 // Return the number of element.
 func (a *AVLTree[K, V]) Len() int {
 	return a.len
@@ -54,30 +138,12 @@ func (a *AVLTree[K, V]) Len() int {
 // If the key value pair entry already exists, it updates the value.
 func (a *AVLTree[K, V]) Insert(key K, value V) {
 	stack := [32]**node[K, V]{}
-	pos := -1
-
-	curr := &a.root
-	for *curr != nil {
-		pos++
-		stack[pos] = curr
-
-		if cmp := a.cmp(key, (*curr).key); cmp < 0 {
-			curr = &(*curr).left
-		} else if cmp > 0 {
-			curr = &(*curr).right
-		} else {
-			break
-		}
-	}
-
+	curr, pos := a.search(key, &stack)
 	if *curr == nil {
-		*curr = &node[K, V]{left: nil, right: nil, key: key, value: value, height: 0}
-		a.len++
-		for ; pos >= 0; pos-- {
-			a.balance(stack[pos])
-		}
+		a.link(&stack, pos, curr, key, value)
 	} else {
 		(*curr).value = value
+		a.refresh(&stack, pos)
 	}
 }
 
@@ -104,9 +170,93 @@ func (a *AVLTree[K, V]) Get(key K) (V, bool) {
 // Remove key entry from the tree.
 func (a *AVLTree[K, V]) Remove(key K) {
 	stack := [32]**node[K, V]{}
-	pos := -1
+	curr, pos := a.search(key, &stack)
+
+	//key does not exist
+	if *curr == nil {
+		return
+	}
+	a.unlink(&stack, pos, curr)
+}
 
-	//find the node to remove
+// Update the value of key in a single search.
+//
+// f receives the current value and the exist indicator,
+// and returns the new value and whether to keep the key.
+// If keep is false, the key is removed or not inserted.
+func (a *AVLTree[K, V]) Compute(key K, f func(old V, exist bool) (value V, keep bool)) {
+	stack := [32]**node[K, V]{}
+	curr, pos := a.search(key, &stack)
+	if *curr == nil {
+		var zero V
+		if value, keep := f(zero, false); keep {
+			a.link(&stack, pos, curr, key, value)
+		}
+	} else if value, keep := f((*curr).value, true); keep {
+		(*curr).value = value
+		a.refresh(&stack, pos)
+	} else {
+		a.unlink(&stack, pos, curr)
+	}
+}
+
+// Remove the element at iter, iter must come from the tree and not be the end.
+//
+// Return an iterator points to the next element.
+// The rebalancing may rotate the path, so the next element is located again by its rank,
+// which descends the tree without calling the predicate.
+func (a *AVLTree[K, V]) RemoveAt(iter Iterator[K, V]) Iterator[K, V] {
+	iter.check()
+	if iter.tree != a {
+		panic("avl_tree: RemoveAt with an iterator of another tree")
+	}
+	if len(iter.stack) == 0 {
+		panic("avl_tree: RemoveAt with an end iterator")
+	}
+
+	//rebuild the links and the rank from the path
+	stack := [32]**node[K, V]{&a.root}
+	rank := iter.stack[len(iter.stack)-1].left.count()
+	for i := 1; i < len(iter.stack); i++ {
+		if parent := iter.stack[i-1]; parent.left == iter.stack[i] {
+			stack[i] = &parent.left
+		} else {
+			stack[i] = &parent.right
+			rank += parent.left.count() + 1
+		}
+	}
+
+	pos := len(iter.stack) - 1
+	a.unlink(&stack, pos, stack[pos])
+	return a.selectIterator(rank)
+}
+
+// Return an iterator points to the i-th smallest key, or the end if i is the length.
+func (a *AVLTree[K, V]) selectIterator(i int) Iterator[K, V] {
+	iter := a.newIterator()
+	if i >= a.len {
+		return iter
+	}
+
+	for curr := a.root; ; {
+		iter.stack = append(iter.stack, curr)
+		if l := curr.left.count(); i < l {
+			curr = curr.left
+		} else if i > l {
+			i -= l + 1
+			curr = curr.right
+		} else {
+			return iter
+		}
+	}
+}
+
+// Search for key, recording the links from the root to the key in stack.
+//
+// Return the link to the key, or the nil link where the key belongs,
+// and the position of the last link recorded.
+func (a *AVLTree[K, V]) search(key K, stack *[32]**node[K, V]) (**node[K, V], int) {
+	pos := -1
 	curr := &a.root
 	for *curr != nil {
 		pos++
@@ -120,12 +270,29 @@ func (a *AVLTree[K, V]) Remove(key K) {
 			break
 		}
 	}
+	return curr, pos
+}
 
-	//key does not exist
-	if *curr == nil {
-		return
+// Link a new node at the nil link curr, then rebalance the ancestors in stack.
+func (a *AVLTree[K, V]) link(stack *[32]**node[K, V], pos int, curr **node[K, V], key K, value V) {
+	if a.combine != nil {
+		n := &augmentedNode[K, V]{node[K, V]{key: key, value: value, size: 1}, value}
+		n.node.agg = &n.agg
+		*curr = &n.node
+	} else {
+		*curr = &node[K, V]{left: nil, right: nil, key: key, value: value, height: 0, size: 1}
+	}
+	a.len++
+	a.mod++
+	for ; pos >= 0; pos-- {
+		a.balance(stack[pos])
 	}
+}
 
+// Remove the node at curr, then rebalance the ancestors in stack.
+//
+// curr must be the last link recorded in stack.
+func (a *AVLTree[K, V]) unlink(stack *[32]**node[K, V], pos int, curr **node[K, V]) {
 	//find the replacement
 	if (*curr).right == nil {
 		*curr = (*curr).left
@@ -145,12 +312,106 @@ func (a *AVLTree[K, V]) Remove(key K) {
 		pos--
 	}
 	a.len--
+	a.mod++
 
 	for ; pos >= 0; pos-- {
 		a.balance(stack[pos])
 	}
 }
 
+// Update the aggregate of the nodes in stack after a value changed.
+func (a *AVLTree[K, V]) refresh(stack *[32]**node[K, V], pos int) {
+	if a.combine != nil {
+		for ; pos >= 0; pos-- {
+			a.update(*stack[pos])
+		}
+	}
+}
+
+// Return the number of keys strictly less than key.
+func (a *AVLTree[K, V]) Rank(key K) int {
+	rank := 0
+	for curr := a.root; curr != nil; {
+		if cmp := a.cmp(key, curr.key); cmp < 0 {
+			curr = curr.left
+		} else if cmp > 0 {
+			rank += curr.left.count() + 1
+			curr = curr.right
+		} else {
+			return rank + curr.left.count()
+		}
+	}
+	return rank
+}
+
+// Return the i-th smallest key value pair, starting from 0.
+//
+// It panics if i is out of range.
+func (a *AVLTree[K, V]) Select(i int) (K, V) {
+	if i < 0 || i >= a.len {
+		panic("avl_tree: Select index out of range")
+	}
+
+	curr := a.root
+	for {
+		if l := curr.left.count(); i < l {
+			curr = curr.left
+		} else if i > l {
+			i -= l + 1
+			curr = curr.right
+		} else {
+			return curr.key, curr.value
+		}
+	}
+}
+
+// Return the aggregate of the values with keys in [lo, hi).
+//
+// It panics if the tree is not created by NewAugmented.
+func (a *AVLTree[K, V]) Aggregate(lo K, hi K) V {
+	if a.combine == nil {
+		panic("avl_tree: Aggregate on a tree without aggregate")
+	}
+
+	//find the highest node within the range, where the search paths of lo and hi diverge
+	curr := a.root
+	for curr != nil {
+		if a.cmp(curr.key, lo) < 0 {
+			curr = curr.right
+		} else if a.cmp(curr.key, hi) >= 0 {
+			curr = curr.left
+		} else {
+			break
+		}
+	}
+	if curr == nil {
+		return a.identity
+	}
+
+	//combine the keys greater than or equal to lo in the left subtree
+	left := a.identity
+	for n := curr.left; n != nil; {
+		if a.cmp(n.key, lo) >= 0 {
+			left = a.combine(a.combine(n.value, a.aggregateOf(n.right)), left)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	//combine the keys less than hi in the right subtree
+	right := a.identity
+	for n := curr.right; n != nil; {
+		if a.cmp(n.key, hi) < 0 {
+			right = a.combine(right, a.combine(a.aggregateOf(n.left), n.value))
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return a.combine(a.combine(left, curr.value), right)
+}
+
 // Return the min key value pair.
 func (a *AVLTree[K, V]) Min() (K, V) {
 	curr := a.root
@@ -169,6 +430,256 @@ func (a *AVLTree[K, V]) Max() (K, V) {
 	return curr.key, curr.value
 }
 
+// Split the tree into two trees.
+//
+// The first one contains the keys less than key, the second one contains the rest.
+// The tree is emptied.
+func (a *AVLTree[K, V]) Split(key K) (AVLTree[K, V], AVLTree[K, V]) {
+	l, m, r := a.split(a.root, key)
+	if m != nil {
+		r = a.join(nil, m, r)
+	}
+	a.clear()
+	return a.with(l), a.with(r)
+}
+
+// Concatenate two trees, every key in left must be less than every key in right.
+//
+// It panics if the key ranges overlap. Both trees are emptied.
+func Join[K any, V any](left *AVLTree[K, V], right *AVLTree[K, V]) AVLTree[K, V] {
+	left.checkOperand(right, "Join")
+	if left.root != nil && right.root != nil {
+		lMax, _ := left.Max()
+		rMin, _ := right.Min()
+		if left.cmp(lMax, rMin) >= 0 {
+			panic("avl_tree: Join key ranges overlap")
+		}
+	}
+
+	return left.consume(right, left.concat(left.root, right.root))
+}
+
+// Build a perfectly balanced subtree out of the preallocated nodes.
+func (a *AVLTree[K, V]) build(nodes []node[K, V], keys []K, values []V) *node[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	mid := len(nodes) / 2
+	n := &nodes[mid]
+	n.key, n.value = keys[mid], values[mid]
+	n.left = a.build(nodes[:mid], keys[:mid], values[:mid])
+	n.right = a.build(nodes[mid+1:], keys[mid+1:], values[mid+1:])
+	a.update(n)
+	return n
+}
+
+// Return a tree with keys in either a or b.
+//
+// For keys in both trees, the value is resolved by resolve(key, value in a, value in b).
+// Both trees are emptied.
+func Union[K any, V any](a *AVLTree[K, V], b *AVLTree[K, V], resolve func(K, V, V) V) AVLTree[K, V] {
+	a.checkOperand(b, "Union")
+	return a.consume(b, a.union(a.root, b.root, resolve))
+}
+
+// Return a tree with keys in both a and b, keeping the values in a.
+//
+// Both trees are emptied.
+func Intersection[K any, V any](a *AVLTree[K, V], b *AVLTree[K, V]) AVLTree[K, V] {
+	a.checkOperand(b, "Intersection")
+	return a.consume(b, a.intersection(a.root, b.root))
+}
+
+// Return a tree with keys in a but not in b.
+//
+// Both trees are emptied.
+func Difference[K any, V any](a *AVLTree[K, V], b *AVLTree[K, V]) AVLTree[K, V] {
+	a.checkOperand(b, "Difference")
+	return a.consume(b, a.difference(a.root, b.root))
+}
+
+// Return a tree with keys in exactly one of a and b.
+//
+// Both trees are emptied.
+func SymmetricDifference[K any, V any](a *AVLTree[K, V], b *AVLTree[K, V]) AVLTree[K, V] {
+	a.checkOperand(b, "SymmetricDifference")
+	return a.consume(b, a.symmetricDifference(a.root, b.root))
+}
+
+// These functions combine two subtrees by splitting one by the root of the other,
+// then recursively combining the halves and joining them back.
+//
+// They run in Θ(m log(n/m + 1)) where m <= n are the subtree sizes.
+
+func (a *AVLTree[K, V]) union(n1 *node[K, V], n2 *node[K, V], resolve func(K, V, V) V) *node[K, V] {
+	if n1 == nil {
+		return n2
+	}
+	if n2 == nil {
+		return n1
+	}
+
+	l2, m2, r2 := a.split(n2, n1.key)
+	l := a.union(n1.left, l2, resolve)
+	r := a.union(n1.right, r2, resolve)
+	if m2 != nil {
+		n1.value = resolve(n1.key, n1.value, m2.value)
+	}
+	return a.join(l, n1, r)
+}
+
+func (a *AVLTree[K, V]) intersection(n1 *node[K, V], n2 *node[K, V]) *node[K, V] {
+	if n1 == nil || n2 == nil {
+		return nil
+	}
+
+	l2, m2, r2 := a.split(n2, n1.key)
+	l := a.intersection(n1.left, l2)
+	r := a.intersection(n1.right, r2)
+	if m2 != nil {
+		return a.join(l, n1, r)
+	}
+	return a.concat(l, r)
+}
+
+func (a *AVLTree[K, V]) difference(n1 *node[K, V], n2 *node[K, V]) *node[K, V] {
+	if n1 == nil || n2 == nil {
+		return n1
+	}
+
+	l1, _, r1 := a.split(n1, n2.key)
+	l := a.difference(l1, n2.left)
+	r := a.difference(r1, n2.right)
+	return a.concat(l, r)
+}
+
+func (a *AVLTree[K, V]) symmetricDifference(n1 *node[K, V], n2 *node[K, V]) *node[K, V] {
+	if n1 == nil {
+		return n2
+	}
+	if n2 == nil {
+		return n1
+	}
+
+	l2, m2, r2 := a.split(n2, n1.key)
+	l := a.symmetricDifference(n1.left, l2)
+	r := a.symmetricDifference(n1.right, r2)
+	if m2 != nil {
+		return a.concat(l, r)
+	}
+	return a.join(l, n1, r)
+}
+
+// Panic if the trees share their nodes, or only one of them is augmented.
+func (a *AVLTree[K, V]) checkOperand(b *AVLTree[K, V], op string) {
+	if a == b {
+		panic("avl_tree: " + op + " a tree with itself")
+	}
+	if (a.combine == nil) != (b.combine == nil) {
+		panic("avl_tree: " + op + " an augmented tree with a plain tree")
+	}
+}
+
+// Remove all the nodes, they are handed over to another tree.
+func (a *AVLTree[K, V]) clear() {
+	a.root, a.len = nil, 0
+	a.mod++
+}
+
+// Empty both trees, return a tree rooted at root that takes over their nodes.
+func (a *AVLTree[K, V]) consume(b *AVLTree[K, V], root *node[K, V]) AVLTree[K, V] {
+	tree := a.with(root)
+	a.clear()
+	b.clear()
+	return tree
+}
+
+// Return a tree rooted at root sharing the same predicate and aggregate.
+func (a *AVLTree[K, V]) with(root *node[K, V]) AVLTree[K, V] {
+	return AVLTree[K, V]{root: root, len: root.count(), cmp: a.cmp, combine: a.combine, identity: a.identity}
+}
+
+// Split the subtree rooted at n by key.
+//
+// Return the keys less than key, the detached node equal to key (or nil), and the keys greater than key.
+func (a *AVLTree[K, V]) split(n *node[K, V], key K) (*node[K, V], *node[K, V], *node[K, V]) {
+	if n == nil {
+		return nil, nil, nil
+	}
+
+	if cmp := a.cmp(key, n.key); cmp < 0 {
+		l, m, r := a.split(n.left, key)
+		return l, m, a.join(r, n, n.right)
+	} else if cmp > 0 {
+		l, m, r := a.split(n.right, key)
+		return a.join(n.left, n, l), m, r
+	}
+
+	l, r := n.left, n.right
+	n.left, n.right = nil, nil
+	a.update(n)
+	return l, n, r
+}
+
+// Join l, m and r into a balanced tree, where l < m < r.
+//
+// It descends the spine of the taller tree until the heights match, in Θ(|height(l) - height(r)|).
+func (a *AVLTree[K, V]) join(l *node[K, V], m *node[K, V], r *node[K, V]) *node[K, V] {
+	if lh, rh := l.treeHeight(), r.treeHeight(); lh > rh+1 {
+		l.right = a.join(l.right, m, r)
+		a.balance(&l)
+		return l
+	} else if rh > lh+1 {
+		r.left = a.join(l, m, r.left)
+		a.balance(&r)
+		return r
+	}
+
+	m.left, m.right = l, r
+	a.update(m)
+	return m
+}
+
+// Join l and r into a balanced tree, where l < r.
+func (a *AVLTree[K, V]) concat(l *node[K, V], r *node[K, V]) *node[K, V] {
+	if l == nil {
+		return r
+	}
+	l, m := a.removeMax(l)
+	return a.join(l, m, r)
+}
+
+// Detach the max node from the subtree rooted at n.
+//
+// Return the new subtree and the detached node.
+func (a *AVLTree[K, V]) removeMax(n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.right == nil {
+		return n.left, n
+	}
+
+	var m *node[K, V]
+	n.right, m = a.removeMax(n.right)
+	a.balance(&n)
+	return n, m
+}
+
+// Update the node height, size and aggregate based on child nodes.
+func (a *AVLTree[K, V]) update(n *node[K, V]) {
+	n.update()
+	if a.combine != nil {
+		*n.agg = a.combine(a.combine(a.aggregateOf(n.left), n.value), a.aggregateOf(n.right))
+	}
+}
+
+// Return the aggregate of the subtree rooted at n.
+func (a *AVLTree[K, V]) aggregateOf(n *node[K, V]) V {
+	if n == nil {
+		return a.identity
+	}
+	return *n.agg
+}
+
 // Balance the subtree rooted at p.
 func (a *AVLTree[K, V]) balance(p **node[K, V]) {
 	if factor := (*p).balanceFactor(); factor < -1 {
@@ -184,7 +695,7 @@ func (a *AVLTree[K, V]) balance(p **node[K, V]) {
 			a.rightLeftRotate(p)
 		}
 	} else {
-		(*p).updateHeight()
+		a.update(*p)
 	}
 }
 
@@ -206,8 +717,8 @@ func (a *AVLTree[K, V]) leftRotate(p **node[K, V]) {
 	y := x.right
 	x.right, y.left = y.left, x
 	*p = y
-	x.updateHeight()
-	y.updateHeight()
+	a.update(x)
+	a.update(y)
 }
 
 func (a *AVLTree[K, V]) rightRotate(p **node[K, V]) {
@@ -224,8 +735,8 @@ func (a *AVLTree[K, V]) rightRotate(p **node[K, V]) {
 	y := x.left
 	x.left, y.right = y.right, x
 	*p = y
-	x.updateHeight()
-	y.updateHeight()
+	a.update(x)
+	a.update(y)
 }
 
 func (a *AVLTree[K, V]) leftRightRotate(p **node[K, V]) {
@@ -245,9 +756,9 @@ func (a *AVLTree[K, V]) leftRightRotate(p **node[K, V]) {
 	y.right, x.left = z.left, z.right
 	z.left, z.right = y, x
 	*p = z
-	x.updateHeight()
-	y.updateHeight()
-	z.updateHeight()
+	a.update(x)
+	a.update(y)
+	a.update(z)
 }
 
 func (a *AVLTree[K, V]) rightLeftRotate(p **node[K, V]) {
@@ -267,18 +778,164 @@ func (a *AVLTree[K, V]) rightLeftRotate(p **node[K, V]) {
 	y.left, x.right = z.right, z.left
 	z.left, z.right = x, y
 	*p = z
-	x.updateHeight()
-	y.updateHeight()
-	z.updateHeight()
+	a.update(x)
+	a.update(y)
+	a.update(z)
 }
 
-// Return an iterator points to the first element.
-func (a *AVLTree[K, V]) Begin() Iterator[K, V] {
+// Return the greatest key value pair less than or equal to key.
+//
+// If such key does not exist, it returns (zero value, zero value, false).
+func (a *AVLTree[K, V]) Floor(key K) (K, V, bool) {
+	return a.bound(key, false, true, nil).entry()
+}
+
+// Return the smallest key value pair greater than or equal to key.
+//
+// If such key does not exist, it returns (zero value, zero value, false).
+func (a *AVLTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return a.bound(key, true, true, nil).entry()
+}
+
+// Return the greatest key value pair strictly less than key.
+//
+// If such key does not exist, it returns (zero value, zero value, false).
+func (a *AVLTree[K, V]) Lower(key K) (K, V, bool) {
+	return a.bound(key, false, false, nil).entry()
+}
+
+// Return the smallest key value pair strictly greater than key.
+//
+// If such key does not exist, it returns (zero value, zero value, false).
+func (a *AVLTree[K, V]) Higher(key K) (K, V, bool) {
+	return a.bound(key, true, false, nil).entry()
+}
+
+// Return an iterator points to the greatest key less than or equal to key.
+//
+// If such key does not exist, the iterator points to the end.
+func (a *AVLTree[K, V]) FloorIterator(key K) Iterator[K, V] {
+	iter := a.newIterator()
+	a.bound(key, false, true, &iter)
+	return iter
+}
+
+// Return an iterator points to the smallest key greater than or equal to key.
+//
+// If such key does not exist, the iterator points to the end.
+func (a *AVLTree[K, V]) CeilingIterator(key K) Iterator[K, V] {
+	iter := a.newIterator()
+	a.bound(key, true, true, &iter)
+	return iter
+}
+
+// Return an iterator points to the greatest key strictly less than key.
+//
+// If such key does not exist, the iterator points to the end.
+func (a *AVLTree[K, V]) LowerIterator(key K) Iterator[K, V] {
+	iter := a.newIterator()
+	a.bound(key, false, false, &iter)
+	return iter
+}
+
+// Return an iterator points to the smallest key strictly greater than key.
+//
+// If such key does not exist, the iterator points to the end.
+func (a *AVLTree[K, V]) HigherIterator(key K) Iterator[K, V] {
+	iter := a.newIterator()
+	a.bound(key, true, false, &iter)
+	return iter
+}
+
+// Options of the range query.
+//
+// The zero value represents the half-open range [lo, hi) in ascending order.
+type RangeOptions struct {
+	ExcludeLo bool // exclude lo from the range
+	IncludeHi bool // include hi in the range
+	Descend   bool // iterate from hi to lo
+}
+
+// Return an iterator over every key value pair between lo and hi.
+//
+// It starts at the bound in Θ(log n) rather than at the first element.
+func (a *AVLTree[K, V]) Range(lo K, hi K, opt RangeOptions) RangeIterator[K, V] {
+	iter := RangeIterator[K, V]{iter: a.newIterator(), descend: opt.Descend, cmp: a.cmp}
+	if opt.Descend {
+		a.bound(hi, false, opt.IncludeHi, &iter.iter)
+		iter.end, iter.inclusive = lo, !opt.ExcludeLo
+	} else {
+		a.bound(lo, true, !opt.ExcludeLo, &iter.iter)
+		iter.end, iter.inclusive = hi, opt.IncludeHi
+	}
+	return iter
+}
+
+// Search for the closest node to key.
+//
+// If greater is true, it looks for the smallest key greater than key,
+// otherwise it looks for the greatest key less than key.
+// If inclusive is true, key itself is also accepted.
+//
+// If iter is not nil, it records the path from the root to the result.
+func (a *AVLTree[K, V]) bound(key K, greater bool, inclusive bool, iter *Iterator[K, V]) *node[K, V] {
+	var result *node[K, V]
+	depth, resultDepth := 0, 0
+	for curr := a.root; curr != nil; {
+		if iter != nil {
+			iter.stack = append(iter.stack, curr)
+		}
+		depth++
+
+		cmp := a.cmp(key, curr.key)
+		if cmp == 0 && inclusive {
+			result, resultDepth = curr, depth
+			break
+		}
+
+		if greater {
+			if cmp < 0 {
+				result, resultDepth = curr, depth
+				curr = curr.left
+			} else {
+				curr = curr.right
+			}
+		} else {
+			if cmp > 0 {
+				result, resultDepth = curr, depth
+				curr = curr.right
+			} else {
+				curr = curr.left
+			}
+		}
+	}
+
+	//trim the path down to the result
+	if iter != nil {
+		iter.stack = iter.stack[:resultDepth]
+	}
+	return result
+}
+
+// Return an empty iterator with enough capacity to hold a path.
+func (a *AVLTree[K, V]) newIterator() Iterator[K, V] {
 	height := 0
 	if a.root != nil {
 		height = a.root.height + 1
 	}
-	iter := Iterator[K, V]{make([]*node[K, V], 0, height)}
+	return Iterator[K, V]{make([]*node[K, V], 0, height), a, a.mod}
+}
+
+// Return an iterator points to the first element.
+func (a *AVLTree[K, V]) Begin() Iterator[K, V] {
+	iter := a.newIterator()
 	iter.addLeftTree(a.root)
 	return iter
 }
+
+// Return a reverse iterator points to the last element.
+func (a *AVLTree[K, V]) RBegin() ReverseIterator[K, V] {
+	iter := a.newIterator()
+	iter.addRightTree(a.root)
+	return ReverseIterator[K, V]{iter}
+}
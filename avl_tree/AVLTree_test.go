@@ -1,8 +1,10 @@
 package avl_tree
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -29,6 +31,18 @@ func assertTree(t *testing.T, tree AVLTree[int, int]) bool {
 			t.Fatalf("balance factor of node %d is %d", root.key, balanceFactor)
 			return false
 		}
+
+		if size := root.left.count() + root.right.count() + 1; root.size != size {
+			t.Fatalf("size of node %d is %d, want %d", root.key, root.size, size)
+			return false
+		}
+
+		if tree.combine != nil {
+			if agg := tree.combine(tree.combine(tree.aggregateOf(root.left), root.value), tree.aggregateOf(root.right)); *root.agg != agg {
+				t.Fatalf("aggregate of node %d is %d, want %d", root.key, *root.agg, agg)
+				return false
+			}
+		}
 		return validateNode(root.left) && validateNode(root.right)
 	}
 
@@ -241,11 +255,700 @@ func TestIterator(t *testing.T) {
 	}
 }
 
+// Build a tree with even keys in [0, 2*testSize) in random order.
+func newEvenTree(testSize int) AVLTree[int, int] {
+	tree := New[int, int](func(a, b int) int { return a - b })
+	for _, key := range rand.Perm(testSize) {
+		tree.Insert(key*2, key)
+	}
+	return tree
+}
+
+// Brute force search the closest even key in [0, 2*testSize) to x.
+func searchEven(testSize, x int, greater bool, inclusive bool) (int, bool) {
+	if greater {
+		for key := 0; key < 2*testSize; key += 2 {
+			if key > x || (inclusive && key == x) {
+				return key, true
+			}
+		}
+	} else {
+		for key := 2*testSize - 2; key >= 0; key -= 2 {
+			if key < x || (inclusive && key == x) {
+				return key, true
+			}
+		}
+	}
+	return 0, false
+}
+
+var boundTests = []struct {
+	name      string
+	greater   bool
+	inclusive bool
+}{
+	{"Floor", false, true},
+	{"Ceiling", true, true},
+	{"Lower", false, false},
+	{"Higher", true, false},
+}
+
+func TestAVLTree_Bound(t *testing.T) {
+	const testSize = 1 << 8
+	tree := newEvenTree(testSize)
+	search := map[string]func(int) (int, int, bool){
+		"Floor": tree.Floor, "Ceiling": tree.Ceiling, "Lower": tree.Lower, "Higher": tree.Higher,
+	}
+
+	for _, test := range boundTests {
+		for x := -3; x < 2*testSize+3; x++ {
+			eKey, eBool := searchEven(testSize, x, test.greater, test.inclusive)
+			aKey, aValue, aBool := search[test.name](x)
+			if eBool != aBool || (eBool && (eKey != aKey || eKey/2 != aValue)) {
+				t.Fatalf("%s(%d) = (%d, %d, %v), want (%d, %d, %v)", test.name, x, aKey, aValue, aBool, eKey, eKey/2, eBool)
+			}
+		}
+	}
+}
+
+func TestAVLTree_BoundIterator(t *testing.T) {
+	const testSize = 1 << 8
+	tree := newEvenTree(testSize)
+	search := map[string]func(int) Iterator[int, int]{
+		"Floor": tree.FloorIterator, "Ceiling": tree.CeilingIterator, "Lower": tree.LowerIterator, "Higher": tree.HigherIterator,
+	}
+
+	for _, test := range boundTests {
+		for x := -3; x < 2*testSize+3; x++ {
+			expected, exist := searchEven(testSize, x, test.greater, test.inclusive)
+			iter := search[test.name](x)
+			if !exist {
+				if iter.HasNext() {
+					key, _ := iter.Get()
+					t.Fatalf("%sIterator(%d) points to %d, want end", test.name, x, key)
+				}
+				continue
+			}
+
+			for ; iter.HasNext(); iter.Next() {
+				if key, _ := iter.Get(); key != expected {
+					t.Fatalf("%sIterator(%d) yields %d, want %d", test.name, x, key, expected)
+				}
+				expected += 2
+			}
+			if expected != 2*testSize {
+				t.Fatalf("%sIterator(%d) stops before %d", test.name, x, expected)
+			}
+		}
+	}
+}
+
+func TestAVLTree_Range(t *testing.T) {
+	const testSize = 1 << 6
+	tree := newEvenTree(testSize)
+
+	for lo := -3; lo < 2*testSize+3; lo++ {
+		for hi := lo - 2; hi < 2*testSize+3; hi++ {
+			for _, opt := range []RangeOptions{
+				{}, {ExcludeLo: true}, {IncludeHi: true}, {ExcludeLo: true, IncludeHi: true},
+				{Descend: true}, {ExcludeLo: true, IncludeHi: true, Descend: true},
+			} {
+				expected := []int{}
+				for key := 0; key < 2*testSize; key += 2 {
+					if (key > lo || (key == lo && !opt.ExcludeLo)) && (key < hi || (key == hi && opt.IncludeHi)) {
+						expected = append(expected, key)
+					}
+				}
+				if opt.Descend {
+					for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+						expected[i], expected[j] = expected[j], expected[i]
+					}
+				}
+
+				actual := []int{}
+				for iter := tree.Range(lo, hi, opt); iter.HasNext(); iter.Next() {
+					key, value := iter.Get()
+					if value != key/2 {
+						t.Fatalf("Range(%d, %d, %+v) yields value %d for key %d", lo, hi, opt, value, key)
+					}
+					actual = append(actual, key)
+				}
+				if fmt.Sprint(actual) != fmt.Sprint(expected) {
+					t.Fatalf("Range(%d, %d, %+v) = %v, want %v", lo, hi, opt, actual, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestReverseIterator(t *testing.T) {
+	const testSize = 1 << 10
+	tree := New[int, int](func(a, b int) int { return a - b })
+	for _, key := range rand.Perm(testSize) {
+		tree.Insert(key, -key)
+	}
+
+	expected := testSize - 1
+	for iter := tree.RBegin(); iter.HasNext(); iter.Next() {
+		if key, value := iter.Get(); key != expected || value != -expected {
+			t.Fatalf("Get() = (%d, %d), want (%d, %d)", key, value, expected, -expected)
+		}
+		expected--
+	}
+	if expected != -1 {
+		t.Fatalf("iteration stops at %d, want -1", expected)
+	}
+}
+
+func TestIterator_Prev(t *testing.T) {
+	const testSize = 1 << 10
+	tree := New[int, int](func(a, b int) int { return a - b })
+	for _, key := range rand.Perm(testSize) {
+		tree.Insert(key, key)
+	}
+
+	//walk forward then step back and forth at every position
+	iter := tree.Begin()
+	for expected := 0; expected < testSize; expected++ {
+		if expected > 0 {
+			iter.Prev()
+			if key, _ := iter.Get(); key != expected-1 {
+				t.Fatalf("Prev() points to %d, want %d", key, expected-1)
+			}
+			iter.Next()
+		}
+		if key, _ := iter.Get(); key != expected {
+			t.Fatalf("Get() = %d, want %d", key, expected)
+		}
+		iter.Next()
+	}
+	if iter.HasNext() {
+		t.Fatalf("HasNext() = true, want false")
+	}
+
+	iter = tree.Begin()
+	iter.Prev()
+	if iter.HasNext() {
+		t.Fatalf("Prev() on the first element, HasNext() = true, want false")
+	}
+}
+
+func TestAVLTree_Rank(t *testing.T) {
+	const testSize = 1 << 8
+	tree := newEvenTree(testSize)
+
+	for x := -3; x < 2*testSize+3; x++ {
+		expected := min(max((x+1)/2, 0), testSize)
+		if actual := tree.Rank(x); actual != expected {
+			t.Fatalf("Rank(%d) = %d, want %d", x, actual, expected)
+		}
+	}
+}
+
+func TestAVLTree_Select(t *testing.T) {
+	const testSize = 1 << 10
+	tree := New[int, int](func(a, b int) int { return a - b })
+	keys := rand.Perm(testSize)
+	for _, key := range keys {
+		tree.Insert(key, -key)
+	}
+	for _, key := range keys[:testSize/2] {
+		tree.Remove(key)
+	}
+	assertTree(t, tree)
+
+	iter := tree.Begin()
+	for i := 0; i < tree.Len(); i++ {
+		eKey, eValue := iter.Get()
+		if aKey, aValue := tree.Select(i); aKey != eKey || aValue != eValue {
+			t.Fatalf("Select(%d) = (%d, %d), want (%d, %d)", i, aKey, aValue, eKey, eValue)
+		}
+		if rank := tree.Rank(eKey); rank != i {
+			t.Fatalf("Rank(%d) = %d, want %d", eKey, rank, i)
+		}
+		iter.Next()
+	}
+}
+
+// Return the keys of the tree in order.
+func treeKeys(tree AVLTree[int, int]) []int {
+	keys := []int{}
+	for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+		key, _ := iter.Get()
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestAVLTree_Split(t *testing.T) {
+	const testSize = 1 << 6
+	for x := -3; x < 2*testSize+3; x++ {
+		tree := newEvenTree(testSize)
+		left, right := tree.Split(x)
+		assertTree(t, left)
+		assertTree(t, right)
+
+		if tree.Len() != 0 {
+			t.Fatalf("Split(%d), Len() = %d, want 0", x, tree.Len())
+		}
+
+		eLeft, eRight := []int{}, []int{}
+		for key := 0; key < 2*testSize; key += 2 {
+			if key < x {
+				eLeft = append(eLeft, key)
+			} else {
+				eRight = append(eRight, key)
+			}
+		}
+		if aLeft, aRight := treeKeys(left), treeKeys(right); fmt.Sprint(aLeft, aRight) != fmt.Sprint(eLeft, eRight) {
+			t.Fatalf("Split(%d) = (%v, %v), want (%v, %v)", x, aLeft, aRight, eLeft, eRight)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	const testSize = 1 << 8
+	cmp := func(a, b int) int { return a - b }
+	for _, split := range []int{0, 1, 2, 5, 17, 100, testSize - 1, testSize} {
+		left, right := New[int, int](cmp), New[int, int](cmp)
+		for _, key := range rand.Perm(testSize) {
+			if key < split {
+				left.Insert(key, key)
+			} else {
+				right.Insert(key, key)
+			}
+		}
+
+		tree := Join(&left, &right)
+		assertTree(t, tree)
+		if left.Len() != 0 || right.Len() != 0 {
+			t.Fatalf("Join() at %d, inputs Len() = (%d, %d), want (0, 0)", split, left.Len(), right.Len())
+		}
+
+		//the inputs no longer share nodes with the result
+		left.Insert(testSize, testSize)
+		right.Insert(-1, -1)
+		if keys := treeKeys(tree); len(keys) != testSize || keys[0] != 0 || keys[testSize-1] != testSize-1 {
+			t.Fatalf("Join() at %d = %v", split, keys)
+		}
+		for i := 0; i < testSize; i++ {
+			if key, _ := tree.Select(i); key != i {
+				t.Fatalf("Join() at %d, Select(%d) = %d, want %d", split, i, key, i)
+			}
+		}
+	}
+}
+
+func TestJoin_Overlap(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Join() with overlapping keys does not panic")
+		}
+	}()
+
+	cmp := func(a, b int) int { return a - b }
+	left, right := New[int, int](cmp), New[int, int](cmp)
+	left.Insert(5, 5)
+	right.Insert(5, 5)
+	Join(&left, &right)
+}
+
+func TestJoin_MixedAugmentation(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Join() of an augmented tree and a plain tree does not panic")
+		}
+	}()
+
+	cmp := func(a, b int) int { return a - b }
+	left := NewAugmented[int, int](cmp, func(a, b int) int { return a + b }, 0)
+	right := New[int, int](cmp)
+	left.Insert(1, 1)
+	right.Insert(2, 2)
+	Join(&left, &right)
+}
+
+func TestFromSorted(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	for testSize := 0; testSize < 1<<8; testSize++ {
+		keys, values := make([]int, testSize), make([]int, testSize)
+		for i := range keys {
+			keys[i], values[i] = i*2, -i
+		}
+
+		tree, err := FromSorted(keys, values, cmp)
+		if err != nil {
+			t.Fatalf("FromSorted() of size %d returns %v", testSize, err)
+		}
+		assertTree(t, tree)
+
+		i := 0
+		for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+			if key, value := iter.Get(); key != keys[i] || value != values[i] {
+				t.Fatalf("Get() = (%d, %d), want (%d, %d)", key, value, keys[i], values[i])
+			}
+			i++
+		}
+
+		//the tree remains usable afterward
+		tree.Insert(-1, 1)
+		tree.Remove(0)
+		assertTree(t, tree)
+	}
+}
+
+func TestFromSorted_Invalid(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	tests := []struct {
+		keys   []int
+		values []int
+		err    error
+	}{
+		{[]int{1, 2, 3}, []int{1, 2}, ErrLengthMismatch},
+		{[]int{1, 3, 2}, []int{1, 2, 3}, ErrUnsorted},
+		{[]int{1, 2, 2}, []int{1, 2, 3}, ErrDuplicateKey},
+	}
+
+	for _, test := range tests {
+		tree, err := FromSorted(test.keys, test.values, cmp)
+		if !errors.Is(err, test.err) {
+			t.Errorf("FromSorted(%v, %v) returns %v, want %v", test.keys, test.values, err, test.err)
+		}
+		if tree.Len() != 0 {
+			t.Errorf("FromSorted(%v, %v), Len() = %d, want 0", test.keys, test.values, tree.Len())
+		}
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	const testSize = 1 << 9
+	cmp := func(a, b int) int { return a - b }
+	resolve := func(key, a, b int) int { return a + b }
+
+	tests := []struct {
+		name    string
+		combine func(*AVLTree[int, int], *AVLTree[int, int]) AVLTree[int, int]
+		want    func(inA, inB bool) bool
+	}{
+		{"Union", func(a, b *AVLTree[int, int]) AVLTree[int, int] { return Union(a, b, resolve) }, func(inA, inB bool) bool { return inA || inB }},
+		{"Intersection", Intersection[int, int], func(inA, inB bool) bool { return inA && inB }},
+		{"Difference", Difference[int, int], func(inA, inB bool) bool { return inA && !inB }},
+		{"SymmetricDifference", SymmetricDifference[int, int], func(inA, inB bool) bool { return inA != inB }},
+	}
+
+	for _, test := range tests {
+		for _, sizes := range [][2]int{{0, 0}, {0, 10}, {10, 0}, {testSize, testSize}, {testSize, 7}, {7, testSize}} {
+			a, b := New[int, int](cmp), New[int, int](cmp)
+			sa, sb := map[int]int{}, map[int]int{}
+			for _, key := range rand.Perm(testSize)[:sizes[0]] {
+				a.Insert(key, key)
+				sa[key] = key
+			}
+			for _, key := range rand.Perm(testSize)[:sizes[1]] {
+				b.Insert(key, key*testSize)
+				sb[key] = key * testSize
+			}
+
+			tree := test.combine(&a, &b)
+			assertTree(t, tree)
+			if a.Len() != 0 || b.Len() != 0 {
+				t.Fatalf("%s() of sizes %v, inputs Len() = (%d, %d), want (0, 0)", test.name, sizes, a.Len(), b.Len())
+			}
+
+			//the inputs no longer share nodes with the result
+			treeLen := tree.Len()
+			a.Insert(testSize, testSize)
+			b.Insert(testSize+1, testSize+1)
+			if tree.Len() != treeLen || len(treeKeys(tree)) != treeLen {
+				t.Fatalf("%s() of sizes %v changed after inserting into the inputs", test.name, sizes)
+			}
+
+			expected := []int{}
+			for key := 0; key < testSize; key++ {
+				_, inA := sa[key]
+				_, inB := sb[key]
+				if test.want(inA, inB) {
+					expected = append(expected, key)
+				}
+			}
+			if actual := treeKeys(tree); fmt.Sprint(actual) != fmt.Sprint(expected) {
+				t.Fatalf("%s() of sizes %v = %v, want %v", test.name, sizes, actual, expected)
+			}
+
+			for _, key := range expected {
+				va, inA := sa[key]
+				vb, inB := sb[key]
+				eValue := va
+				if !inA {
+					eValue = vb
+				} else if inB && test.name == "Union" {
+					eValue = resolve(key, va, vb)
+				}
+				if aValue, _ := tree.Get(key); aValue != eValue {
+					t.Fatalf("%s() of sizes %v, Get(%d) = %d, want %d", test.name, sizes, key, aValue, eValue)
+				}
+			}
+		}
+	}
+}
+
+func TestAVLTree_Aggregate(t *testing.T) {
+	const testSize = 1 << 7
+	tree := NewAugmented[int, int](func(a, b int) int { return a - b }, func(a, b int) int { return a + b }, 0)
+	sTree := map[int]int{}
+
+	for i := 0; i < 4*testSize; i++ {
+		key := rand.Intn(testSize)
+		if rand.Intn(3) == 0 {
+			tree.Remove(key)
+			delete(sTree, key)
+		} else {
+			tree.Insert(key, i)
+			sTree[key] = i
+		}
+		assertTree(t, tree)
+
+		lo, hi := rand.Intn(testSize+2)-1, rand.Intn(testSize+2)-1
+		expected := 0
+		for k, v := range sTree {
+			if lo <= k && k < hi {
+				expected += v
+			}
+		}
+		if actual := tree.Aggregate(lo, hi); actual != expected {
+			t.Fatalf("Aggregate(%d, %d) = %d, want %d", lo, hi, actual, expected)
+		}
+	}
+
+	//the aggregate survives split and join
+	left, right := tree.Split(testSize / 2)
+	assertTree(t, left)
+	assertTree(t, right)
+	tree = Join(&left, &right)
+	assertTree(t, tree)
+}
+
+func TestAVLTree_AggregateNotAugmented(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Aggregate() on a tree created by New does not panic")
+		}
+	}()
+
+	tree := New[int, int](func(a, b int) int { return a - b })
+	tree.Insert(1, 1)
+	tree.Aggregate(0, 2)
+}
+
+func TestIterator_Invalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*AVLTree[int, int])
+		panics bool
+	}{
+		{"Insert new key", func(tree *AVLTree[int, int]) { tree.Insert(100, 100) }, true},
+		{"Insert existing key", func(tree *AVLTree[int, int]) { tree.Insert(5, 100) }, false},
+		{"Remove existing key", func(tree *AVLTree[int, int]) { tree.Remove(5) }, true},
+		{"Remove missing key", func(tree *AVLTree[int, int]) { tree.Remove(100) }, false},
+		{"Split", func(tree *AVLTree[int, int]) { tree.Split(5) }, true},
+		{"Join", func(tree *AVLTree[int, int]) { other := New[int, int](tree.cmp); Join(tree, &other) }, true},
+		{"Union", func(tree *AVLTree[int, int]) { other := New[int, int](tree.cmp); Union(&other, tree, nil) }, true},
+		{"Intersection", func(tree *AVLTree[int, int]) { other := New[int, int](tree.cmp); Intersection(tree, &other) }, true},
+		{"Difference", func(tree *AVLTree[int, int]) { other := New[int, int](tree.cmp); Difference(&other, tree) }, true},
+		{"SymmetricDifference", func(tree *AVLTree[int, int]) { other := New[int, int](tree.cmp); SymmetricDifference(tree, &other) }, true},
+	}
+
+	for _, test := range tests {
+		tree := New[int, int](func(a, b int) int { return a - b })
+		for i := 0; i < 10; i++ {
+			tree.Insert(i, i)
+		}
+
+		func() {
+			defer func() {
+				if panicked := recover() != nil; panicked != test.panics {
+					t.Errorf("%s during iteration, panic = %v, want %v", test.name, panicked, test.panics)
+				}
+			}()
+
+			for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+				if key, _ := iter.Get(); key == 3 {
+					test.modify(&tree)
+				}
+			}
+		}()
+	}
+}
+
+func TestAVLTree_Compute(t *testing.T) {
+	const testSize = 1 << 7
+	tree := NewAugmented[int, int](func(a, b int) int { return a - b }, func(a, b int) int { return a + b }, 0)
+	sTree := map[int]int{}
+
+	for i := 0; i < 8*testSize; i++ {
+		key, delta := rand.Intn(testSize), rand.Intn(5)-2
+		tree.Compute(key, func(old int, exist bool) (int, bool) {
+			if eOld, eExist := sTree[key]; old != eOld || exist != eExist {
+				t.Fatalf("Compute(%d) receives (%d, %v), want (%d, %v)", key, old, exist, eOld, eExist)
+			}
+			return old + delta, old+delta > 0
+		})
+
+		if value := sTree[key] + delta; value > 0 {
+			sTree[key] = value
+		} else {
+			delete(sTree, key)
+		}
+
+		if tree.Len() != len(sTree) {
+			t.Fatalf("Len() = %d, want %d", tree.Len(), len(sTree))
+		}
+		eValue, eBool := sTree[key]
+		if aValue, aBool := tree.Get(key); eValue != aValue || eBool != aBool {
+			t.Fatalf("Get(%d) = (%d, %v), want (%d, %v)", key, aValue, aBool, eValue, eBool)
+		}
+		assertTree(t, tree)
+	}
+}
+
+func TestIterator_SetValue(t *testing.T) {
+	const testSize = 1 << 8
+	tree := NewAugmented[int, int](func(a, b int) int { return a - b }, func(a, b int) int { return a + b }, 0)
+	for _, key := range rand.Perm(testSize) {
+		tree.Insert(key, key)
+	}
+
+	for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+		key, value := iter.Get()
+		iter.SetValue(value * 2)
+		if actual, _ := tree.Get(key); actual != key*2 {
+			t.Fatalf("SetValue(%d), Get(%d) = %d", key*2, key, actual)
+		}
+	}
+	assertTree(t, tree)
+
+	if sum, expected := tree.Aggregate(0, testSize), testSize*(testSize-1); sum != expected {
+		t.Fatalf("Aggregate() = %d, want %d", sum, expected)
+	}
+}
+
+func TestAVLTree_RemoveAt(t *testing.T) {
+	const testSize = 1 << 10
+	tree := New[int, int](func(a, b int) int { return a - b })
+	for _, key := range rand.Perm(testSize) {
+		tree.Insert(key, key)
+	}
+
+	//remove the multiples of 3 during the walk
+	expected := 0
+	for iter := tree.Begin(); iter.HasNext(); {
+		key, _ := iter.Get()
+		if key != expected {
+			t.Fatalf("Get() = %d, want %d", key, expected)
+		}
+		expected++
+
+		if key%3 == 0 {
+			iter = tree.RemoveAt(iter)
+			assertTree(t, tree)
+		} else {
+			iter.Next()
+		}
+	}
+
+	if expected := testSize - (testSize+2)/3; tree.Len() != expected {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), expected)
+	}
+	for key := 0; key < testSize; key++ {
+		if _, ok := tree.Get(key); ok != (key%3 != 0) {
+			t.Fatalf("Get(%d) exists = %v, want %v", key, ok, key%3 != 0)
+		}
+	}
+}
+
+func TestAVLTree_RemoveAtNoCompare(t *testing.T) {
+	calls := 0
+	tree := New[int, int](func(a, b int) int { calls++; return a - b })
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+
+	iter := tree.CeilingIterator(50)
+	calls = 0
+	iter = tree.RemoveAt(iter)
+	if calls != 0 {
+		t.Fatalf("RemoveAt() calls the predicate %d times, want 0", calls)
+	}
+	if key, _ := iter.Get(); key != 51 {
+		t.Fatalf("RemoveAt() returns an iterator at %d, want 51", key)
+	}
+	if iter.Prev(); iter.HasNext() {
+		if key, _ := iter.Get(); key != 49 {
+			t.Fatalf("Prev() after RemoveAt() = %d, want 49", key)
+		}
+	}
+}
+
+func TestAVLTree_RemoveAtEnd(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("RemoveAt() with an end iterator does not panic")
+		}
+	}()
+
+	tree := New[int, int](func(a, b int) int { return a - b })
+	tree.Insert(1, 1)
+	tree.RemoveAt(tree.HigherIterator(1))
+}
+
+func TestIterator_MoveEnd(t *testing.T) {
+	tree := New[int, int](func(a, b int) int { return a - b })
+	for key := 0; key < 8; key++ {
+		tree.Insert(key, key)
+	}
+
+	tests := []struct {
+		name string
+		move func()
+	}{
+		{"Prev() after the last key", func() {
+			iter := tree.FloorIterator(7)
+			iter.Next()
+			iter.Prev()
+		}},
+		{"Next() before the first key", func() {
+			iter := tree.Begin()
+			iter.Prev()
+			iter.Next()
+		}},
+		{"Prev() on an empty tree", func() {
+			empty := New[int, int](func(a, b int) int { return a - b })
+			iter := empty.Begin()
+			iter.Prev()
+		}},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != "avl_tree: moving an end iterator" {
+					t.Fatalf("%s panics with %v", test.name, r)
+				}
+			}()
+			test.move()
+		}()
+	}
+}
+
 func BenchmarkAVLTree_Insert_Small(b *testing.B) {
 	// int64
 	// BenchmarkAVLTree_Insert_Small-16    	 6201751	       199.5 ns/op	      48 B/op	       1 allocs/op
 	// BenchmarkAVLTree_Insert_Small-16    	 6220729	       201.5 ns/op	      48 B/op	       1 allocs/op
 	// BenchmarkAVLTree_Insert_Small-16    	 5196642	       209.7 ns/op	      48 B/op	       1 allocs/op
+	//
+	// measured on a slower machine after adding the size and aggregate pointer fields
+	// BenchmarkAVLTree_Insert_Small 	 1789982	       598.3 ns/op	      64 B/op	       1 allocs/op
+	// BenchmarkAVLTree_Insert_Small 	 1881177	       611.6 ns/op	      64 B/op	       1 allocs/op
+	// BenchmarkAVLTree_Insert_Small 	 1828828	       629.2 ns/op	      64 B/op	       1 allocs/op
 
 	tree := New[int64, int64](func(a, b int64) int { return int(a - b) })
 	b.ResetTimer()
@@ -259,6 +962,11 @@ func BenchmarkAVLTree_Insert_Big(b *testing.B) {
 	// BenchmarkAVLTree_Insert_Big-16    	 2670862	       421.3 ns/op	     192 B/op	       1 allocs/op
 	// BenchmarkAVLTree_Insert_Big-16    	 2667331	       413.9 ns/op	     192 B/op	       1 allocs/op
 	// BenchmarkAVLTree_Insert_Big-16    	 2506341	       416.8 ns/op	     192 B/op	       1 allocs/op
+	//
+	// measured on a slower machine after adding the size and aggregate pointer fields
+	// BenchmarkAVLTree_Insert_Big   	 2093518	       609.3 ns/op	     208 B/op	       1 allocs/op
+	// BenchmarkAVLTree_Insert_Big   	 2093865	       874.8 ns/op	     208 B/op	       1 allocs/op
+	// BenchmarkAVLTree_Insert_Big   	 2021695	       570.4 ns/op	     208 B/op	       1 allocs/op
 
 	type Large [20]int64
 	tree := New[Large, int64](func(a, b Large) int { return int(a[0] - b[0]) })
@@ -273,6 +981,36 @@ func BenchmarkAVLTree_Insert_Big(b *testing.B) {
 	}
 }
 
+func benchmarkFromSorted(b *testing.B, size int) {
+	keys := make([]int64, size)
+	for i := range keys {
+		keys[i] = int64(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FromSortedTrusted(keys, keys, func(a, b int64) int { return int(a - b) })
+	}
+}
+
+func BenchmarkFromSorted_1K(b *testing.B) {
+	// int64, 1 << 10 keys
+	// BenchmarkFromSorted_1K        	   41224	     31730 ns/op	   49152 B/op	       1 allocs/op
+	// BenchmarkFromSorted_1K        	   33654	     38956 ns/op	   49152 B/op	       1 allocs/op
+	// BenchmarkFromSorted_1K        	   40442	     25930 ns/op	   49152 B/op	       1 allocs/op
+
+	benchmarkFromSorted(b, 1<<10)
+}
+
+func BenchmarkFromSorted_1M(b *testing.B) {
+	// int64, 1 << 20 keys
+	// BenchmarkFromSorted_1M        	      31	  40009766 ns/op	50331648 B/op	       1 allocs/op
+	// BenchmarkFromSorted_1M        	      25	  43543574 ns/op	50331648 B/op	       1 allocs/op
+	// BenchmarkFromSorted_1M        	      21	  53584548 ns/op	50331648 B/op	       1 allocs/op
+
+	benchmarkFromSorted(b, 1<<20)
+}
+
 func ExampleAVLTree_Insert() {
 	tree := New[int, int](func(a, b int) int { return a - b })
 	tree.Insert(10, 20)
@@ -354,3 +1092,207 @@ func ExampleIterator() {
 	// 12 54
 	// 15 12
 }
+
+func ExampleAVLTree_Floor() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+
+	fmt.Println(tree.Floor(25))
+	fmt.Println(tree.Floor(5))
+	// Output:
+	// 20 b true
+	// 0  false
+}
+
+func ExampleAVLTree_CeilingIterator() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+
+	for iter := tree.CeilingIterator(15); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 20 b
+	// 30 c
+}
+
+func ExampleAVLTree_Range() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+	tree.Insert(40, "d")
+
+	for iter := tree.Range(20, 40, RangeOptions{}); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	for iter := tree.Range(20, 40, RangeOptions{IncludeHi: true, Descend: true}); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 20 b
+	// 30 c
+	// 40 d
+	// 30 c
+	// 20 b
+}
+
+func ExampleReverseIterator() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+
+	for iter := tree.RBegin(); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 30 c
+	// 20 b
+	// 10 a
+}
+
+func ExampleAVLTree_Rank() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+
+	fmt.Println(tree.Rank(20))
+	fmt.Println(tree.Rank(25))
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleAVLTree_Select() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+
+	fmt.Println(tree.Select(0))
+	fmt.Println(tree.Select(2))
+	// Output:
+	// 10 a
+	// 30 c
+}
+
+func ExampleAVLTree_Split() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+
+	left, right := tree.Split(20)
+	fmt.Println(left.Len(), right.Len())
+	fmt.Println(right.Min())
+	// Output:
+	// 1 2
+	// 20 b
+}
+
+func ExampleJoin() {
+	cmp := func(a, b int) int { return a - b }
+	left := New[int, string](cmp)
+	left.Insert(10, "a")
+	right := New[int, string](cmp)
+	right.Insert(20, "b")
+	right.Insert(30, "c")
+
+	tree := Join(&left, &right)
+	for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 10 a
+	// 20 b
+	// 30 c
+}
+
+func ExampleFromSorted() {
+	tree, err := FromSorted([]int{10, 20, 30}, []string{"a", "b", "c"}, func(a, b int) int { return a - b })
+	fmt.Println(tree.Len(), err)
+
+	_, err = FromSorted([]int{10, 30, 20}, []string{"a", "b", "c"}, func(a, b int) int { return a - b })
+	fmt.Println(err)
+	// Output:
+	// 3 <nil>
+	// avl_tree: keys are not sorted at index 2
+}
+
+func ExampleUnion() {
+	cmp := func(a, b int) int { return a - b }
+	a := New[int, int](cmp)
+	a.Insert(1, 10)
+	a.Insert(2, 20)
+	b := New[int, int](cmp)
+	b.Insert(2, 200)
+	b.Insert(3, 300)
+
+	tree := Union(&a, &b, func(key, a, b int) int { return a + b })
+	for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 1 10
+	// 2 220
+	// 3 300
+}
+
+func ExampleAVLTree_Aggregate() {
+	tree := NewAugmented[int, string](func(a, b int) int { return a - b }, func(a, b string) string { return a + b }, "")
+	tree.Insert(4, "d")
+	tree.Insert(1, "a")
+	tree.Insert(3, "c")
+	tree.Insert(2, "b")
+	tree.Insert(5, "e")
+
+	fmt.Println(tree.Aggregate(2, 5))
+	fmt.Println(tree.Aggregate(0, 100))
+	// Output:
+	// bcd
+	// abcde
+}
+
+func ExampleAVLTree_Compute() {
+	tree := New[string, int](func(a, b string) int { return strings.Compare(a, b) })
+	for _, word := range strings.Fields("a b a c a b") {
+		tree.Compute(word, func(count int, _ bool) (int, bool) {
+			return count + 1, true
+		})
+	}
+
+	for iter := tree.Begin(); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// a 3
+	// b 2
+	// c 1
+}
+
+func ExampleAVLTree_RemoveAt() {
+	tree := New[int, string](func(a, b int) int { return a - b })
+	tree.Insert(1, "a")
+	tree.Insert(2, "b")
+	tree.Insert(3, "c")
+	tree.Insert(4, "d")
+
+	for iter := tree.Begin(); iter.HasNext(); {
+		if key, _ := iter.Get(); key%2 == 0 {
+			iter = tree.RemoveAt(iter)
+		} else {
+			iter.Next()
+		}
+	}
+	fmt.Println(tree.Len())
+	fmt.Println(tree.Max())
+	// Output:
+	// 2
+	// 3 c
+}
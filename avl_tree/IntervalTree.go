@@ -0,0 +1,144 @@
+package avl_tree
+
+// A half-open interval [Start, End).
+type Interval[T any] struct {
+	Start T
+	End   T
+}
+
+// The key stored in the underlying tree, it carries the value but is ordered by the interval only.
+type intervalKey[T any, V any] struct {
+	Interval[T]
+	value V
+}
+
+// The value stored in the underlying tree, which is also the aggregate of a subtree.
+//
+// It is the max end of the intervals, or invalid if the subtree is empty.
+type maxEnd[T any] struct {
+	end   T
+	valid bool
+}
+
+// An interval tree that maps [start, end) intervals to values.
+//
+// It is an AVL tree ordered by (start, end) that caches the max end of every subtree,
+// which prunes the subtrees that cannot overlap a query.
+type IntervalTree[T any, V any] struct {
+	tree AVLTree[intervalKey[T, V], maxEnd[T]]
+	cmp  func(T, T) int
+}
+
+func NewIntervalTree[T any, V any](predicate func(T, T) int) IntervalTree[T, V] {
+	compare := func(a, b intervalKey[T, V]) int {
+		if cmp := predicate(a.Start, b.Start); cmp != 0 {
+			return cmp
+		}
+		return predicate(a.End, b.End)
+	}
+	combine := func(a, b maxEnd[T]) maxEnd[T] {
+		if !a.valid || (b.valid && predicate(b.end, a.end) > 0) {
+			return b
+		}
+		return a
+	}
+	return IntervalTree[T, V]{NewAugmented(compare, combine, maxEnd[T]{}), predicate}
+}
+
+// Return the number of element.
+func (i *IntervalTree[T, V]) Len() int {
+	return i.tree.Len()
+}
+
+// Insert an interval value pair to the tree.
+//
+// If the interval already exists, it updates the value.
+func (i *IntervalTree[T, V]) Insert(interval Interval[T], value V) {
+	key := intervalKey[T, V]{interval, value}
+	stack := [32]**node[intervalKey[T, V], maxEnd[T]]{}
+	curr, pos := i.tree.search(key, &stack)
+	if *curr == nil {
+		i.tree.link(&stack, pos, curr, key, maxEnd[T]{interval.End, true})
+	} else {
+		//the value is not part of the aggregate
+		(*curr).key.value = value
+	}
+}
+
+// Return the value and the exist indicator of the interval.
+func (i *IntervalTree[T, V]) Get(interval Interval[T]) (V, bool) {
+	stack := [32]**node[intervalKey[T, V], maxEnd[T]]{}
+	if curr, _ := i.tree.search(intervalKey[T, V]{Interval: interval}, &stack); *curr != nil {
+		return (*curr).key.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Remove the interval from the tree.
+func (i *IntervalTree[T, V]) Remove(interval Interval[T]) {
+	i.tree.Remove(intervalKey[T, V]{Interval: interval})
+}
+
+// Visit every interval containing point in ascending order, in O((k + 1) log n).
+func (i *IntervalTree[T, V]) Stab(point T, visit func(Interval[T], V)) {
+	i.overlap(i.tree.root, point, point, true, visit)
+}
+
+// Visit every interval intersecting [lo, hi) in ascending order, in O((k + 1) log n).
+//
+// It visits nothing if hi <= lo, since [lo, hi) is empty.
+func (i *IntervalTree[T, V]) Overlap(lo T, hi T, visit func(Interval[T], V)) {
+	if i.cmp(hi, lo) <= 0 {
+		return
+	}
+	i.overlap(i.tree.root, lo, hi, false, visit)
+}
+
+// Visit the intervals intersecting [lo, hi) in the subtree rooted at n.
+//
+// If point is true, it visits the intervals containing lo instead, where hi equals lo.
+func (i *IntervalTree[T, V]) overlap(n *node[intervalKey[T, V], maxEnd[T]], lo T, hi T, point bool, visit func(Interval[T], V)) {
+	//every interval in the subtree ends before lo
+	if n == nil || i.cmp(n.agg.end, lo) <= 0 {
+		return
+	}
+
+	i.overlap(n.left, lo, hi, point, visit)
+
+	//every interval in the right subtree starts at or after n
+	cmp := i.cmp(n.key.Start, hi)
+	if cmp > 0 || (cmp == 0 && !point) {
+		return
+	}
+	if i.cmp(n.key.End, lo) > 0 {
+		visit(n.key.Interval, n.key.value)
+	}
+	i.overlap(n.right, lo, hi, point, visit)
+}
+
+// Return an iterator points to the first interval ordered by (start, end).
+func (i *IntervalTree[T, V]) Begin() IntervalIterator[T, V] {
+	return IntervalIterator[T, V]{i.tree.Begin()}
+}
+
+// Iterator that iterate through the intervals ordered by (start, end).
+type IntervalIterator[T any, V any] struct {
+	iter Iterator[intervalKey[T, V], maxEnd[T]]
+}
+
+// Return the interval value pair.
+func (i *IntervalIterator[T, V]) Get() (Interval[T], V) {
+	key, _ := i.iter.Get()
+	return key.Interval, key.value
+}
+
+// Advance the iterator.
+func (i *IntervalIterator[T, V]) Next() {
+	i.iter.Next()
+}
+
+// Return true if the iterator is not the end.
+func (i *IntervalIterator[T, V]) HasNext() bool {
+	return i.iter.HasNext()
+}
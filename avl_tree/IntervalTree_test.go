@@ -0,0 +1,95 @@
+package avl_tree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestIntervalTree(t *testing.T) {
+	const testSize = 1 << 7
+	tree := NewIntervalTree[int, int](func(a, b int) int { return a - b })
+	sTree := map[Interval[int]]int{}
+
+	for i := 0; i < 4*testSize; i++ {
+		start := rand.Intn(testSize)
+		interval := Interval[int]{start, start + rand.Intn(testSize/4) + 1}
+		if rand.Intn(3) == 0 {
+			tree.Remove(interval)
+			delete(sTree, interval)
+		} else {
+			tree.Insert(interval, i)
+			sTree[interval] = i
+		}
+
+		if tree.Len() != len(sTree) {
+			t.Fatalf("Len() = %d, want %d", tree.Len(), len(sTree))
+		}
+
+		//the result is sorted, so compare the counts and the order
+		lo := rand.Intn(testSize+4) - 2
+		hi := lo + rand.Intn(testSize/4)
+		check := func(name string, contains func(Interval[int]) bool, query func(func(Interval[int], int))) {
+			count := 0
+			var last *Interval[int]
+			query(func(interval Interval[int], value int) {
+				if !contains(interval) || sTree[interval] != value {
+					t.Fatalf("%s visits (%v, %d)", name, interval, value)
+				}
+				if last != nil && (last.Start > interval.Start || (last.Start == interval.Start && last.End >= interval.End)) {
+					t.Fatalf("%s visits %v after %v", name, interval, *last)
+				}
+				last = &interval
+				count++
+			})
+
+			expected := 0
+			for interval := range sTree {
+				if contains(interval) {
+					expected++
+				}
+			}
+			if count != expected {
+				t.Fatalf("%s visits %d intervals, want %d", name, count, expected)
+			}
+		}
+
+		check(fmt.Sprintf("Stab(%d)", lo),
+			func(i Interval[int]) bool { return i.Start <= lo && lo < i.End },
+			func(visit func(Interval[int], int)) { tree.Stab(lo, visit) })
+		check(fmt.Sprintf("Overlap(%d, %d)", lo, hi),
+			func(i Interval[int]) bool { return lo < hi && i.Start < hi && lo < i.End },
+			func(visit func(Interval[int], int)) { tree.Overlap(lo, hi, visit) })
+	}
+}
+
+func TestIntervalTree_OverlapEmpty(t *testing.T) {
+	tree := NewIntervalTree[int, int](func(a, b int) int { return a - b })
+	tree.Insert(Interval[int]{0, 10}, 0)
+	tree.Insert(Interval[int]{5, 6}, 1)
+
+	for _, query := range []Interval[int]{{5, 5}, {6, 5}, {0, 0}} {
+		tree.Overlap(query.Start, query.End, func(interval Interval[int], value int) {
+			t.Fatalf("Overlap(%d, %d) visits (%v, %d)", query.Start, query.End, interval, value)
+		})
+	}
+}
+
+func ExampleIntervalTree() {
+	tree := NewIntervalTree[int, string](func(a, b int) int { return a - b })
+	tree.Insert(Interval[int]{9, 12}, "standup")
+	tree.Insert(Interval[int]{10, 11}, "review")
+	tree.Insert(Interval[int]{13, 15}, "lunch")
+
+	tree.Stab(10, func(interval Interval[int], name string) {
+		fmt.Println(interval, name)
+	})
+	tree.Overlap(11, 14, func(interval Interval[int], name string) {
+		fmt.Println(interval, name)
+	})
+	// Output:
+	// {9 12} standup
+	// {10 11} review
+	// {9 12} standup
+	// {13 15} lunch
+}
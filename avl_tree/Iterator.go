@@ -1,16 +1,56 @@
 package avl_tree
 
 // Iterator that iterate through the tree using in-order traversal.
+//
+// It keeps the path from the root to the current node, so it can move in both directions.
+//
+// Inserting a new key, removing a key, or emptying the tree by Split, Join or the set operations
+// invalidates the iterator, using it afterward panics.
+// Updating the value of an existing key does not.
 type Iterator[K any, V any] struct {
 	stack []*node[K, V]
+	tree  *AVLTree[K, V] // nil if the tree is immutable
+	mod   uint
+}
+
+// Panic if the tree is structurally modified after the iterator is created.
+func (i *Iterator[K, V]) check() {
+	if i.tree != nil && i.tree.mod != i.mod {
+		panic("avl_tree: iterator is invalidated by a modification of the tree")
+	}
+}
+
+// Panic if the iterator is the end.
+func (i *Iterator[K, V]) checkEnd() {
+	if len(i.stack) == 0 {
+		panic("avl_tree: moving an end iterator")
+	}
 }
 
 // Return the key value pair.
 func (i *Iterator[K, V]) Get() (K, V) {
+	i.check()
 	node := i.stack[len(i.stack)-1]
 	return node.key, node.value
 }
 
+// Set the value.
+//
+// It panics if the tree is immutable.
+func (i *Iterator[K, V]) SetValue(value V) {
+	i.check()
+	if i.tree == nil {
+		panic("avl_tree: SetValue on an immutable tree")
+	}
+
+	i.stack[len(i.stack)-1].value = value
+	if i.tree.combine != nil {
+		for j := len(i.stack) - 1; j >= 0; j-- {
+			i.tree.update(i.stack[j])
+		}
+	}
+}
+
 // Add all the left child rooted at root to the stack.
 func (i *Iterator[K, V]) addLeftTree(root *node[K, V]) {
 	for ; root != nil; root = root.left {
@@ -18,15 +58,121 @@ func (i *Iterator[K, V]) addLeftTree(root *node[K, V]) {
 	}
 }
 
+// Add all the right child rooted at root to the stack.
+func (i *Iterator[K, V]) addRightTree(root *node[K, V]) {
+	for ; root != nil; root = root.right {
+		i.stack = append(i.stack, root)
+	}
+}
+
 // Advance the iterator.
+//
+// It panics if the iterator is the end.
 func (i *Iterator[K, V]) Next() {
+	i.check()
+	i.checkEnd()
 	len := len(i.stack) - 1
 	node := i.stack[len]
+	if node.right != nil {
+		i.addLeftTree(node.right)
+		return
+	}
+
+	//climb up until coming from a left child
 	i.stack = i.stack[:len]
-	i.addLeftTree(node.right)
+	for len = len - 1; len >= 0 && i.stack[len].right == node; len-- {
+		node = i.stack[len]
+		i.stack = i.stack[:len]
+	}
 }
 
 // Return true if the iterator is not the end.
 func (i *Iterator[K, V]) HasNext() bool {
+	i.check()
 	return len(i.stack) > 0
 }
+
+// Move the iterator backward.
+//
+// Moving backward from the first element reaches the end.
+// It panics if the iterator is the end, because the end does not remember the direction it came from.
+func (i *Iterator[K, V]) Prev() {
+	i.check()
+	i.checkEnd()
+	len := len(i.stack) - 1
+	node := i.stack[len]
+	if node.left != nil {
+		i.addRightTree(node.left)
+		return
+	}
+
+	//climb up until coming from a right child
+	i.stack = i.stack[:len]
+	for len = len - 1; len >= 0 && i.stack[len].left == node; len-- {
+		node = i.stack[len]
+		i.stack = i.stack[:len]
+	}
+}
+
+// Iterator that iterate through the tree using reverse in-order traversal.
+type ReverseIterator[K any, V any] struct {
+	iter Iterator[K, V]
+}
+
+// Return the key value pair.
+func (r *ReverseIterator[K, V]) Get() (K, V) {
+	return r.iter.Get()
+}
+
+// Advance the iterator toward the smaller keys.
+func (r *ReverseIterator[K, V]) Next() {
+	r.iter.Prev()
+}
+
+// Move the iterator backward toward the greater keys.
+//
+// Moving backward from the first element reaches the end, moving the end panics.
+func (r *ReverseIterator[K, V]) Prev() {
+	r.iter.Next()
+}
+
+// Return true if the iterator is not the end.
+func (r *ReverseIterator[K, V]) HasNext() bool {
+	return r.iter.HasNext()
+}
+
+// Iterator that iterate through the keys within a range.
+type RangeIterator[K any, V any] struct {
+	iter      Iterator[K, V]
+	end       K
+	inclusive bool
+	descend   bool
+	cmp       func(K, K) int
+}
+
+// Return the key value pair.
+func (r *RangeIterator[K, V]) Get() (K, V) {
+	return r.iter.Get()
+}
+
+// Advance the iterator.
+func (r *RangeIterator[K, V]) Next() {
+	if r.descend {
+		r.iter.Prev()
+	} else {
+		r.iter.Next()
+	}
+}
+
+// Return true if the iterator is still within the range.
+func (r *RangeIterator[K, V]) HasNext() bool {
+	if !r.iter.HasNext() {
+		return false
+	}
+
+	cmp := r.cmp(r.iter.stack[len(r.iter.stack)-1].key, r.end)
+	if r.descend {
+		cmp = -cmp
+	}
+	return cmp < 0 || (cmp == 0 && r.inclusive)
+}
@@ -0,0 +1,107 @@
+package avl_tree
+
+// An ordered map that allows duplicate keys.
+//
+// The values of equal keys are kept in insertion order.
+type MultiMap[K any, V any] struct {
+	tree AVLTree[K, []V]
+	len  int
+}
+
+func NewMultiMap[K any, V any](predicate func(K, K) int) MultiMap[K, V] {
+	return MultiMap[K, V]{tree: New[K, []V](predicate)}
+}
+
+// Return the number of key value pair.
+func (m *MultiMap[K, V]) Len() int {
+	return m.len
+}
+
+// Insert a key value pair after the existing values of key.
+func (m *MultiMap[K, V]) Insert(key K, value V) {
+	m.tree.Compute(key, func(values []V, _ bool) ([]V, bool) {
+		return append(values, value), true
+	})
+	m.len++
+}
+
+// Return the number of values of key.
+func (m *MultiMap[K, V]) Count(key K) int {
+	values, _ := m.tree.Get(key)
+	return len(values)
+}
+
+// Return a copy of the values of key in insertion order.
+func (m *MultiMap[K, V]) GetAll(key K) []V {
+	values, _ := m.tree.Get(key)
+	return append([]V(nil), values...)
+}
+
+// Remove the earliest inserted value of key.
+//
+// Return true if a value is removed.
+func (m *MultiMap[K, V]) RemoveOne(key K) bool {
+	removed := false
+	m.tree.Compute(key, func(values []V, exist bool) ([]V, bool) {
+		if !exist {
+			return nil, false
+		}
+
+		//zero it for the GC to clean up
+		var zero V
+		values[0] = zero
+		removed = true
+		return values[1:], len(values) > 1
+	})
+
+	if removed {
+		m.len--
+	}
+	return removed
+}
+
+// Remove all the values of key.
+//
+// Return the number of values removed.
+func (m *MultiMap[K, V]) RemoveAll(key K) int {
+	count := 0
+	m.tree.Compute(key, func(values []V, _ bool) ([]V, bool) {
+		count = len(values)
+		return nil, false
+	})
+	m.len -= count
+	return count
+}
+
+// Return an iterator points to the first key value pair.
+func (m *MultiMap[K, V]) Begin() MultiMapIterator[K, V] {
+	return MultiMapIterator[K, V]{m.tree.Begin(), 0}
+}
+
+// Iterator that iterate through the key value pairs in key order.
+//
+// The values of equal keys are yielded adjacently in insertion order.
+type MultiMapIterator[K any, V any] struct {
+	iter  Iterator[K, []V]
+	index int
+}
+
+// Return the key value pair.
+func (i *MultiMapIterator[K, V]) Get() (K, V) {
+	key, values := i.iter.Get()
+	return key, values[i.index]
+}
+
+// Advance the iterator.
+func (i *MultiMapIterator[K, V]) Next() {
+	i.index++
+	if _, values := i.iter.Get(); i.index == len(values) {
+		i.iter.Next()
+		i.index = 0
+	}
+}
+
+// Return true if the iterator is not the end.
+func (i *MultiMapIterator[K, V]) HasNext() bool {
+	return i.iter.HasNext()
+}
@@ -0,0 +1,82 @@
+package avl_tree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestMultiMap(t *testing.T) {
+	const testSize = 1 << 6
+	multiMap := NewMultiMap[int, int](func(a, b int) int { return a - b })
+	sMap := map[int][]int{}
+	sLen := 0
+
+	for i := 0; i < 16*testSize; i++ {
+		key := rand.Intn(testSize)
+		switch rand.Intn(4) {
+		case 0:
+			removed := multiMap.RemoveOne(key)
+			if removed != (len(sMap[key]) > 0) {
+				t.Fatalf("RemoveOne(%d) = %v, want %v", key, removed, !removed)
+			}
+			if removed {
+				sMap[key] = sMap[key][1:]
+				sLen--
+			}
+		case 1:
+			if count := multiMap.RemoveAll(key); count != len(sMap[key]) {
+				t.Fatalf("RemoveAll(%d) = %d, want %d", key, count, len(sMap[key]))
+			}
+			sLen -= len(sMap[key])
+			delete(sMap, key)
+		default:
+			multiMap.Insert(key, i)
+			sMap[key] = append(sMap[key], i)
+			sLen++
+		}
+
+		if multiMap.Len() != sLen {
+			t.Fatalf("Len() = %d, want %d", multiMap.Len(), sLen)
+		}
+		if count := multiMap.Count(key); count != len(sMap[key]) {
+			t.Fatalf("Count(%d) = %d, want %d", key, count, len(sMap[key]))
+		}
+		if values := multiMap.GetAll(key); fmt.Sprint(values) != fmt.Sprint(append([]int{}, sMap[key]...)) {
+			t.Fatalf("GetAll(%d) = %v, want %v", key, values, sMap[key])
+		}
+	}
+
+	expected := []string{}
+	for key := 0; key < testSize; key++ {
+		for _, value := range sMap[key] {
+			expected = append(expected, fmt.Sprint(key, value))
+		}
+	}
+	actual := []string{}
+	for iter := multiMap.Begin(); iter.HasNext(); iter.Next() {
+		actual = append(actual, fmt.Sprint(iter.Get()))
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Fatalf("iteration = %v, want %v", actual, expected)
+	}
+}
+
+func ExampleMultiMap() {
+	multiMap := NewMultiMap[int, string](func(a, b int) int { return a - b })
+	multiMap.Insert(20, "c")
+	multiMap.Insert(10, "a")
+	multiMap.Insert(20, "d")
+	multiMap.Insert(10, "b")
+
+	fmt.Println(multiMap.Len(), multiMap.Count(10))
+	multiMap.RemoveOne(20)
+	for iter := multiMap.Begin(); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 4 2
+	// 10 a
+	// 10 b
+	// 20 d
+}
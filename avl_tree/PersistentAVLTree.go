@@ -0,0 +1,197 @@
+package avl_tree
+
+// A persistent AVL tree.
+//
+// Insert and Remove return a new version sharing the unchanged subtrees with the old one.
+// Every version is immutable, so it can be read concurrently without locks.
+type PersistentAVLTree[K any, V any] struct {
+	root *node[K, V]
+	len  int
+	cmp  func(K, K) int
+}
+
+func NewPersistent[K any, V any](predicate func(K, K) int) PersistentAVLTree[K, V] {
+	return PersistentAVLTree[K, V]{cmp: predicate}
+}
+
+// Return the number of element.
+func (p PersistentAVLTree[K, V]) Len() int {
+	return p.len
+}
+
+// Return a new version with the key value pair inserted.
+//
+// If the key value pair entry already exists, it updates the value.
+func (p PersistentAVLTree[K, V]) Insert(key K, value V) PersistentAVLTree[K, V] {
+	root, added := p.insert(p.root, key, value)
+	p.root = root
+	if added {
+		p.len++
+	}
+	return p
+}
+
+// Return the value and the exist indicator.
+//
+// If the key exists, it returns (value, true).
+//
+// Otherwise, it returns (zero value, false).
+func (p PersistentAVLTree[K, V]) Get(key K) (V, bool) {
+	for curr := p.root; curr != nil; {
+		if cmp := p.cmp(key, curr.key); cmp < 0 {
+			curr = curr.left
+		} else if cmp > 0 {
+			curr = curr.right
+		} else {
+			return curr.value, true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Return a new version with the key entry removed.
+//
+// If the key does not exist, it returns the same version.
+func (p PersistentAVLTree[K, V]) Remove(key K) PersistentAVLTree[K, V] {
+	if root, removed := p.remove(p.root, key); removed {
+		p.root = root
+		p.len--
+	}
+	return p
+}
+
+// Return the min key value pair.
+func (p PersistentAVLTree[K, V]) Min() (K, V) {
+	curr := p.root
+	for curr.left != nil {
+		curr = curr.left
+	}
+	return curr.key, curr.value
+}
+
+// Return the max key value pair.
+func (p PersistentAVLTree[K, V]) Max() (K, V) {
+	curr := p.root
+	for curr.right != nil {
+		curr = curr.right
+	}
+	return curr.key, curr.value
+}
+
+// Return an iterator points to the first element.
+func (p PersistentAVLTree[K, V]) Begin() Iterator[K, V] {
+	iter := Iterator[K, V]{make([]*node[K, V], 0, p.root.treeHeight()+1), nil, 0}
+	iter.addLeftTree(p.root)
+	return iter
+}
+
+// Insert the key value pair to a copy of the subtree rooted at n.
+//
+// Return the new subtree and true if the key is new.
+func (p PersistentAVLTree[K, V]) insert(n *node[K, V], key K, value V) (*node[K, V], bool) {
+	if n == nil {
+		return &node[K, V]{key: key, value: value, height: 0, size: 1}, true
+	}
+
+	clone := *n
+	added := false
+	if cmp := p.cmp(key, n.key); cmp < 0 {
+		clone.left, added = p.insert(n.left, key, value)
+	} else if cmp > 0 {
+		clone.right, added = p.insert(n.right, key, value)
+	} else {
+		clone.value = value
+		return &clone, false
+	}
+	return p.balance(&clone), added
+}
+
+// Remove the key from a copy of the subtree rooted at n.
+//
+// Return the new subtree and true if the key existed.
+// If the key does not exist, n is returned untouched.
+func (p PersistentAVLTree[K, V]) remove(n *node[K, V], key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var clone node[K, V]
+	if cmp := p.cmp(key, n.key); cmp < 0 {
+		left, removed := p.remove(n.left, key)
+		if !removed {
+			return n, false
+		}
+		clone = *n
+		clone.left = left
+	} else if cmp > 0 {
+		right, removed := p.remove(n.right, key)
+		if !removed {
+			return n, false
+		}
+		clone = *n
+		clone.right = right
+	} else if n.left == nil {
+		return n.right, true
+	} else if n.right == nil {
+		return n.left, true
+	} else {
+		//replace with the successor
+		right, successor := p.removeMin(n.right)
+		clone = *successor
+		clone.left, clone.right = n.left, right
+	}
+	return p.balance(&clone), true
+}
+
+// Remove the min node from a copy of the subtree rooted at n.
+//
+// Return the new subtree and the removed node.
+func (p PersistentAVLTree[K, V]) removeMin(n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+
+	left, m := p.removeMin(n.left)
+	clone := *n
+	clone.left = left
+	return p.balance(&clone), m
+}
+
+// Balance the copied node n, copying the children it rotates.
+func (p PersistentAVLTree[K, V]) balance(n *node[K, V]) *node[K, V] {
+	if factor := n.balanceFactor(); factor < -1 {
+		if n.left.balanceFactor() > 0 {
+			left := *n.left
+			n.left = p.leftRotate(&left)
+		}
+		return p.rightRotate(n)
+	} else if factor > 1 {
+		if n.right.balanceFactor() < 0 {
+			right := *n.right
+			n.right = p.rightRotate(&right)
+		}
+		return p.leftRotate(n)
+	}
+	n.update()
+	return n
+}
+
+// These functions rotate the copied node x, copying the child pulled up.
+
+func (p PersistentAVLTree[K, V]) leftRotate(x *node[K, V]) *node[K, V] {
+	y := *x.right
+	x.right, y.left = y.left, x
+	x.update()
+	y.update()
+	return &y
+}
+
+func (p PersistentAVLTree[K, V]) rightRotate(x *node[K, V]) *node[K, V] {
+	y := *x.left
+	x.left, y.right = y.right, x
+	x.update()
+	y.update()
+	return &y
+}
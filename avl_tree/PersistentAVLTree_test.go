@@ -0,0 +1,122 @@
+package avl_tree
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func assertPersistentTree(t *testing.T, tree PersistentAVLTree[int, int], expected map[int]int) {
+	assertTree(t, AVLTree[int, int]{root: tree.root, len: tree.len, cmp: tree.cmp})
+	for key, eValue := range expected {
+		if aValue, ok := tree.Get(key); !ok || aValue != eValue {
+			t.Fatalf("Get(%d) = (%d, %v), want (%d, true)", key, aValue, ok, eValue)
+		}
+	}
+}
+
+func TestPersistentAVLTree_Insert(t *testing.T) {
+	const testSize = 1 << 8
+	tree := NewPersistent[int, int](func(a, b int) int { return a - b })
+	versions := []PersistentAVLTree[int, int]{tree}
+	snapshots := []map[int]int{{}}
+
+	keys := append(rand.Perm(testSize), rand.Perm(testSize)...)
+	for i, key := range keys {
+		tree = tree.Insert(key, i)
+		snapshot := map[int]int{}
+		for k, v := range snapshots[len(snapshots)-1] {
+			snapshot[k] = v
+		}
+		snapshot[key] = i
+		versions = append(versions, tree)
+		snapshots = append(snapshots, snapshot)
+	}
+
+	//every old version remains intact
+	for i, version := range versions {
+		if version.Len() != len(snapshots[i]) {
+			t.Fatalf("version %d, Len() = %d, want %d", i, version.Len(), len(snapshots[i]))
+		}
+		assertPersistentTree(t, version, snapshots[i])
+	}
+}
+
+func TestPersistentAVLTree_Remove(t *testing.T) {
+	const testSize = 1 << 8
+	tree := NewPersistent[int, int](func(a, b int) int { return a - b })
+	expected := map[int]int{}
+	for _, key := range rand.Perm(testSize) {
+		tree = tree.Insert(key, key)
+		expected[key] = key
+	}
+	full := tree
+
+	keys := append(rand.Perm(2*testSize), rand.Perm(2*testSize)...)
+	for _, key := range keys {
+		tree = tree.Remove(key)
+		delete(expected, key)
+
+		if tree.Len() != len(expected) {
+			t.Fatalf("Len() = %d, want %d", tree.Len(), len(expected))
+		}
+		if _, ok := tree.Get(key); ok {
+			t.Fatalf("Get(%d) after Remove() exists", key)
+		}
+		assertPersistentTree(t, tree, expected)
+	}
+
+	if full.Len() != testSize {
+		t.Fatalf("original version, Len() = %d, want %d", full.Len(), testSize)
+	}
+	i := 0
+	for iter := full.Begin(); iter.HasNext(); iter.Next() {
+		if key, value := iter.Get(); key != i || value != i {
+			t.Fatalf("original version, Get() = (%d, %d), want (%d, %d)", key, value, i, i)
+		}
+		i++
+	}
+}
+
+func TestPersistentAVLTree_Concurrent(t *testing.T) {
+	const testSize = 1 << 10
+	tree := NewPersistent[int, int](func(a, b int) int { return a - b })
+	for i := 0; i < testSize; i++ {
+		tree = tree.Insert(i, i)
+	}
+
+	//readers walk the snapshot while the writer keeps deriving new versions
+	var wg sync.WaitGroup
+	snapshot := tree
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < testSize; i++ {
+				if value, ok := snapshot.Get(i); !ok || value != i {
+					t.Errorf("Get(%d) = (%d, %v), want (%d, true)", i, value, ok, i)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < testSize; i++ {
+		tree = tree.Remove(i).Insert(i, -i)
+	}
+	wg.Wait()
+}
+
+func ExamplePersistentAVLTree() {
+	v1 := NewPersistent[int, string](func(a, b int) int { return a - b })
+	v1 = v1.Insert(10, "a").Insert(20, "b")
+	v2 := v1.Insert(30, "c").Remove(10)
+
+	fmt.Println(v1.Len(), v2.Len())
+	fmt.Println(v1.Get(10))
+	fmt.Println(v2.Get(10))
+	// Output:
+	// 2 2
+	// a true
+	//  false
+}
@@ -0,0 +1,107 @@
+package avl_tree
+
+// An ordered set of unique keys.
+//
+// It shares the balancing logic of AVLTree, the empty values take no memory.
+type Set[K any] struct {
+	tree AVLTree[K, struct{}]
+}
+
+func NewSet[K any](predicate func(K, K) int) Set[K] {
+	return Set[K]{New[K, struct{}](predicate)}
+}
+
+// Return the number of element.
+func (s *Set[K]) Len() int {
+	return s.tree.Len()
+}
+
+// Add key to the set.
+func (s *Set[K]) Add(key K) {
+	s.tree.Insert(key, struct{}{})
+}
+
+// Return true if key is in the set.
+func (s *Set[K]) Contains(key K) bool {
+	_, ok := s.tree.Get(key)
+	return ok
+}
+
+// Remove key from the set.
+func (s *Set[K]) Remove(key K) {
+	s.tree.Remove(key)
+}
+
+// Return the min key.
+func (s *Set[K]) Min() K {
+	key, _ := s.tree.Min()
+	return key
+}
+
+// Return the max key.
+func (s *Set[K]) Max() K {
+	key, _ := s.tree.Max()
+	return key
+}
+
+// Return the greatest key less than or equal to key, and the exist indicator.
+func (s *Set[K]) Floor(key K) (K, bool) {
+	key, _, ok := s.tree.Floor(key)
+	return key, ok
+}
+
+// Return the smallest key greater than or equal to key, and the exist indicator.
+func (s *Set[K]) Ceiling(key K) (K, bool) {
+	key, _, ok := s.tree.Ceiling(key)
+	return key, ok
+}
+
+// Return the greatest key strictly less than key, and the exist indicator.
+func (s *Set[K]) Lower(key K) (K, bool) {
+	key, _, ok := s.tree.Lower(key)
+	return key, ok
+}
+
+// Return the smallest key strictly greater than key, and the exist indicator.
+func (s *Set[K]) Higher(key K) (K, bool) {
+	key, _, ok := s.tree.Higher(key)
+	return key, ok
+}
+
+// Return an iterator points to the first key.
+func (s *Set[K]) Begin() SetIterator[K] {
+	return SetIterator[K]{s.tree.Begin()}
+}
+
+// Return an iterator points to the smallest key greater than or equal to key.
+func (s *Set[K]) CeilingIterator(key K) SetIterator[K] {
+	return SetIterator[K]{s.tree.CeilingIterator(key)}
+}
+
+// Iterator that iterate through the set in ascending order.
+type SetIterator[K any] struct {
+	iter Iterator[K, struct{}]
+}
+
+// Return the key.
+func (i *SetIterator[K]) Get() K {
+	key, _ := i.iter.Get()
+	return key
+}
+
+// Advance the iterator.
+func (i *SetIterator[K]) Next() {
+	i.iter.Next()
+}
+
+// Move the iterator backward.
+//
+// Moving backward from the first key reaches the end.
+func (i *SetIterator[K]) Prev() {
+	i.iter.Prev()
+}
+
+// Return true if the iterator is not the end.
+func (i *SetIterator[K]) HasNext() bool {
+	return i.iter.HasNext()
+}
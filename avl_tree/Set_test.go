@@ -0,0 +1,70 @@
+package avl_tree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	const testSize = 1 << 9
+	set := NewSet(func(a, b int) int { return a - b })
+	sSet := map[int]bool{}
+
+	for i := 0; i < 4*testSize; i++ {
+		key := rand.Intn(testSize)
+		if rand.Intn(3) == 0 {
+			set.Remove(key)
+			delete(sSet, key)
+		} else {
+			set.Add(key)
+			sSet[key] = true
+		}
+
+		if set.Len() != len(sSet) {
+			t.Fatalf("Len() = %d, want %d", set.Len(), len(sSet))
+		}
+		probe := rand.Intn(testSize)
+		if set.Contains(probe) != sSet[probe] {
+			t.Fatalf("Contains(%d) = %v, want %v", probe, set.Contains(probe), sSet[probe])
+		}
+	}
+
+	expected := -1
+	for iter := set.Begin(); iter.HasNext(); iter.Next() {
+		key := iter.Get()
+		if key <= expected || !sSet[key] {
+			t.Fatalf("Get() = %d after %d", key, expected)
+		}
+		if floor, ok := set.Floor(key); !ok || floor != key {
+			t.Fatalf("Floor(%d) = (%d, %v), want (%d, true)", key, floor, ok, key)
+		}
+		if lower, ok := set.Lower(key); ok != (expected >= 0) || (ok && lower != expected) {
+			t.Fatalf("Lower(%d) = (%d, %v), want %d", key, lower, ok, expected)
+		}
+		expected = key
+	}
+	if set.Len() > 0 && (set.Max() != expected) {
+		t.Fatalf("Max() = %d, want %d", set.Max(), expected)
+	}
+}
+
+func ExampleSet() {
+	set := NewSet(func(a, b int) int { return a - b })
+	set.Add(30)
+	set.Add(10)
+	set.Add(20)
+	set.Add(10)
+
+	fmt.Println(set.Len(), set.Contains(20), set.Contains(25))
+	fmt.Println(set.Ceiling(25))
+	for iter := set.Begin(); iter.HasNext(); iter.Next() {
+		fmt.Println(iter.Get())
+	}
+	// Output:
+	// 3 true false
+	// 30 true
+	// 10
+	// 20
+	// 30
+}
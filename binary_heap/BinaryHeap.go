@@ -1,6 +1,10 @@
 package binary_heap
 
-import "github.com/evanhyd/sgl/adt"
+import (
+	"slices"
+
+	"github.com/evanhyd/sgl/adt"
+)
 
 // A max binary heap.
 //
@@ -16,12 +20,15 @@ func New[T any](predicate func(T, T) int) BinaryHeap[T] {
 	return BinaryHeap[T]{cmp: predicate}
 }
 
+// Create a heap with room for capacity elements.
+func NewWithCapacity[T any](capacity int, predicate func(T, T) int) BinaryHeap[T] {
+	return BinaryHeap[T]{slice: make([]T, 0, capacity), cmp: predicate}
+}
+
 // Heapify the slice using cmp as predicate.
 func Heapify[T any](slice []T, cmp func(T, T) int) BinaryHeap[T] {
-	heap := BinaryHeap[T]{slice, cmp}
-	for i := len(heap.slice)/2 - 1; i >= 0; i-- {
-		heap.fixDown(i)
-	}
+	heap := BinaryHeap[T]{slice: slice, cmp: cmp}
+	heapify[T, arity2](heap.slice, heap.cmp)
 	return heap
 }
 
@@ -35,20 +42,48 @@ func (d *BinaryHeap[T]) Cap() int {
 	return cap(d.slice)
 }
 
+// Grow the capacity to at least n.
+func (b *BinaryHeap[T]) Reserve(n int) {
+	if n > cap(b.slice) {
+		slice := make([]T, len(b.slice), n)
+		copy(slice, b.slice)
+		b.slice = slice
+	}
+}
+
+// Release the unused capacity.
+func (b *BinaryHeap[T]) ShrinkToFit() {
+	if cap(b.slice) > len(b.slice) {
+		slice := make([]T, len(b.slice))
+		copy(slice, b.slice)
+		b.slice = slice
+	}
+}
+
+// Remove all the elements, the capacity is kept.
+//
+// It also zero them for the GC to clean up.
+func (b *BinaryHeap[T]) Clear() {
+	clear(b.slice)
+	b.slice = b.slice[:0]
+}
+
 // Add e to the heap.
 func (b *BinaryHeap[T]) Push(e T) {
 	b.slice = append(b.slice, e)
 	b.fixUp(len(b.slice) - 1)
 }
 
-// Remove the top element from the heap.
-func (b *BinaryHeap[T]) Pop() {
+// Remove and return the top element from the heap.
+func (b *BinaryHeap[T]) Pop() T {
+	top := b.slice[0]
 	last := len(b.slice) - 1
 	b.slice[0] = b.slice[last]
 	var zero T
 	b.slice[last] = zero
 	b.slice = b.slice[:last]
 	b.fixDown(0)
+	return top
 }
 
 // Return the top of the heap.
@@ -56,6 +91,57 @@ func (b *BinaryHeap[T]) Top() T {
 	return b.slice[0]
 }
 
+// Push e then pop the top element in a single sift, return the popped element.
+//
+// If e is not less than the top, it returns e without touching the heap.
+func (b *BinaryHeap[T]) PushPop(e T) T {
+	if len(b.slice) == 0 || b.cmp(e, b.slice[0]) >= 0 {
+		return e
+	}
+	return b.Replace(e)
+}
+
+// Pop the top element then push e in a single sift, return the popped element.
+//
+// The heap must not be empty.
+func (b *BinaryHeap[T]) Replace(e T) T {
+	top := b.slice[0]
+	b.slice[0] = e
+	b.fixDown(0)
+	return top
+}
+
+// Restore the heap property after the element at i is changed.
+//
+// It is equivalent to but cheaper than removing the element and pushing it again.
+func (b *BinaryHeap[T]) Fix(i int) {
+	b.fixUp(i)
+	b.fixDown(i)
+}
+
+// Remove all the elements, return them from the top to the bottom.
+//
+// It sorts the underlying array in place without allocation, then leaves the heap empty.
+func (b *BinaryHeap[T]) Drain() []T {
+	slice := b.slice
+	b.sortDown()
+	slices.Reverse(slice)
+	b.slice = nil
+	return slice
+}
+
+// Return a copy of the elements sorted from the top to the bottom.
+//
+// It is faster than walking the Iterator.
+func (b *BinaryHeap[T]) Sorted() []T {
+	sorted := make([]T, len(b.slice))
+	copy(sorted, b.slice)
+	heap := BinaryHeap[T]{slice: sorted, cmp: b.cmp}
+	heap.sortDown()
+	slices.Reverse(sorted)
+	return sorted
+}
+
 // Return an iterator points to the top.
 func (d *BinaryHeap[T]) Begin() Iterator[T] {
 	return newIterator(d)
@@ -63,10 +149,36 @@ func (d *BinaryHeap[T]) Begin() Iterator[T] {
 
 // Fix the heap property start from child upward.
 func (b *BinaryHeap[T]) fixUp(child int) {
+	siftUp[T, arity2](b.slice, b.cmp, child)
+}
+
+// Fix the heap property rooted at root downward.
+func (b *BinaryHeap[T]) fixDown(root int) {
+	siftDown[T, arity2](b.slice, b.cmp, root)
+}
+
+// Return the left child of i.
+func (b *BinaryHeap[T]) left(i int) int {
+	return i*2 + 1
+}
+
+// Two children per node.
+type arity2 [2]struct{}
+
+// The number of children per node, given by the length of the array.
+//
+// It is a type parameter instead of a field, so every arity compiles to its own sift with constant index arithmetic.
+type arity interface {
+	arity2 | Arity4 | Arity8
+}
+
+// Fix the heap property of the slice start from child upward.
+func siftUp[T any, D arity](slice []T, cmp func(T, T) int, child int) {
+	var d D
 	for i := child; i > 0; {
-		p := b.parent(i)
-		if b.cmp(b.slice[i], b.slice[p]) > 0 {
-			b.slice[i], b.slice[p] = b.slice[p], b.slice[i]
+		p := (i - 1) / len(d)
+		if cmp(slice[i], slice[p]) > 0 {
+			slice[i], slice[p] = slice[p], slice[i]
 			i = p
 		} else {
 			break
@@ -74,19 +186,24 @@ func (b *BinaryHeap[T]) fixUp(child int) {
 	}
 }
 
-// Fix the heap property rooted at root downward.
-func (b *BinaryHeap[T]) fixDown(root int) {
-	for end := len(b.slice) / 2; root < end; {
-		child := b.left(root)
+// Fix the heap property of the slice rooted at root downward.
+func siftDown[T any, D arity](slice []T, cmp func(T, T) int, root int) {
+	var d D
+	for {
+		child := root*len(d) + 1
+		if child >= len(slice) {
+			break
+		}
 
-		if r := child + 1; r < len(b.slice) {
-			if b.cmp(b.slice[r], b.slice[child]) > 0 {
-				child = r
+		//find the greatest child
+		for c, end := child+1, min(child+len(d), len(slice)); c < end; c++ {
+			if cmp(slice[c], slice[child]) > 0 {
+				child = c
 			}
 		}
 
-		if b.cmp(b.slice[child], b.slice[root]) > 0 {
-			b.slice[root], b.slice[child] = b.slice[child], b.slice[root]
+		if cmp(slice[child], slice[root]) > 0 {
+			slice[root], slice[child] = slice[child], slice[root]
 			root = child
 		} else {
 			break
@@ -94,12 +211,10 @@ func (b *BinaryHeap[T]) fixDown(root int) {
 	}
 }
 
-// Return the parent of i.
-func (b *BinaryHeap[T]) parent(i int) int {
-	return (i - 1) / 2
-}
-
-// Return the left child of i.
-func (b *BinaryHeap[T]) left(i int) int {
-	return i*2 + 1
+// Fix the heap property of the whole slice.
+func heapify[T any, D arity](slice []T, cmp func(T, T) int) {
+	var d D
+	for i := (len(slice) - 2) / len(d); i >= 0; i-- {
+		siftDown[T, D](slice, cmp, i)
+	}
 }
@@ -64,6 +64,71 @@ func TestBinaryHeap_Cap(t *testing.T) {
 	}
 }
 
+func TestNewWithCapacity(t *testing.T) {
+	heap := NewWithCapacity(100, func(a, b int) int { return a - b })
+	if heap.Len() != 0 || heap.Cap() != 100 {
+		t.Errorf("(Len(), Cap()) = (%v, %v), want (0, 100)", heap.Len(), heap.Cap())
+	}
+
+	for i := 0; i < 100; i++ {
+		heap.Push(i)
+	}
+	if heap.Cap() != 100 {
+		t.Errorf("Cap() = %v, want 100", heap.Cap())
+	}
+	checkHeapProperty(heap, t)
+}
+
+func TestBinaryHeap_Reserve(t *testing.T) {
+	slice := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	heap := Heapify(slice, func(a, b int) int { return a - b })
+
+	heap.Reserve(5)
+	if heap.Cap() != len(slice) {
+		t.Errorf("Reserve(5), Cap() = %v, want %v", heap.Cap(), len(slice))
+	}
+
+	heap.Reserve(100)
+	if heap.Cap() != 100 || heap.Len() != len(slice) {
+		t.Errorf("Reserve(100), (Len(), Cap()) = (%v, %v), want (%v, 100)", heap.Len(), heap.Cap(), len(slice))
+	}
+	if heap.Top() != 9 {
+		t.Errorf("Top() = %v, want 9", heap.Top())
+	}
+	checkHeapProperty(heap, t)
+}
+
+func TestBinaryHeap_ShrinkToFit(t *testing.T) {
+	heap := NewWithCapacity(100, func(a, b int) int { return a - b })
+	for i := 0; i < 10; i++ {
+		heap.Push(i)
+	}
+
+	heap.ShrinkToFit()
+	if heap.Len() != 10 || heap.Cap() != 10 {
+		t.Errorf("ShrinkToFit(), (Len(), Cap()) = (%v, %v), want (10, 10)", heap.Len(), heap.Cap())
+	}
+	if heap.Top() != 9 {
+		t.Errorf("Top() = %v, want 9", heap.Top())
+	}
+	checkHeapProperty(heap, t)
+}
+
+func TestBinaryHeap_Clear(t *testing.T) {
+	slice := []*int{new(int), new(int), new(int)}
+	heap := Heapify(slice, func(a, b *int) int { return *a - *b })
+
+	heap.Clear()
+	if heap.Len() != 0 || heap.Cap() != len(slice) {
+		t.Errorf("Clear(), (Len(), Cap()) = (%v, %v), want (0, %v)", heap.Len(), heap.Cap(), len(slice))
+	}
+	for i, p := range slice {
+		if p != nil {
+			t.Errorf("Clear() does not zero the element at %d", i)
+		}
+	}
+}
+
 func TestBinaryHeap_Push(t *testing.T) {
 	slice := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
 	cmp := func(a, b int) int { return a - b }
@@ -97,6 +162,114 @@ func TestBinaryHeap_Pop(t *testing.T) {
 	}
 }
 
+func TestBinaryHeap_PopValue(t *testing.T) {
+	const size = 100
+	heap := Heapify(rand.Perm(size), func(a, b int) int { return a - b })
+
+	for i := size - 1; i >= 0; i-- {
+		if actual := heap.Pop(); actual != i {
+			t.Fatalf("Pop() = %v, want %v", actual, i)
+		}
+	}
+}
+
+func TestBinaryHeap_PushPop(t *testing.T) {
+	const size = 100
+	heap := Heapify(rand.Perm(size), func(a, b int) int { return a - b })
+
+	// Pushing a greater element returns it right away
+	if actual := heap.PushPop(size); actual != size {
+		t.Errorf("PushPop(%v) = %v, want %v", size, actual, size)
+	}
+
+	// Pushing a smaller element pops the top
+	for i := 0; i < size; i++ {
+		if actual, expected := heap.PushPop(-i), size-1-i; actual != expected {
+			t.Fatalf("PushPop(%v) = %v, want %v", -i, actual, expected)
+		}
+		checkHeapProperty(heap, t)
+	}
+	if heap.Len() != size {
+		t.Errorf("Len() = %v, want %v", heap.Len(), size)
+	}
+
+	empty := New(func(a, b int) int { return a - b })
+	if actual := empty.PushPop(1); actual != 1 || empty.Len() != 0 {
+		t.Errorf("PushPop(1) on empty heap = %v, Len() = %v, want 1, 0", actual, empty.Len())
+	}
+}
+
+func TestBinaryHeap_Replace(t *testing.T) {
+	const size = 100
+	heap := Heapify(rand.Perm(size), func(a, b int) int { return a - b })
+
+	// Replace pops before pushing, even if the new element is greater
+	if actual := heap.Replace(size); actual != size-1 {
+		t.Errorf("Replace(%v) = %v, want %v", size, actual, size-1)
+	}
+	if actual := heap.Replace(-1); actual != size {
+		t.Errorf("Replace(-1) = %v, want %v", actual, size)
+	}
+	checkHeapProperty(heap, t)
+	if heap.Len() != size {
+		t.Errorf("Len() = %v, want %v", heap.Len(), size)
+	}
+}
+
+func TestBinaryHeap_Fix(t *testing.T) {
+	const size = 100
+	slice := rand.Perm(size)
+	heap := Heapify(slice, func(a, b int) int { return a - b })
+
+	for i := 0; i < 4*size; i++ {
+		j := rand.Intn(size)
+		slice[j] = rand.Intn(2 * size)
+		heap.Fix(j)
+		checkHeapProperty(heap, t)
+	}
+}
+
+func TestBinaryHeap_Drain(t *testing.T) {
+	for size := 0; size < 128; size++ {
+		heap := Heapify(rand.Perm(size), func(a, b int) int { return a - b })
+		drained := heap.Drain()
+
+		if heap.Len() != 0 {
+			t.Fatalf("Drain(), Len() = %v, want 0", heap.Len())
+		}
+		if len(drained) != size {
+			t.Fatalf("len(Drain()) = %v, want %v", len(drained), size)
+		}
+		for i, e := range drained {
+			if expected := size - 1 - i; e != expected {
+				t.Fatalf("Drain()[%d] = %v, want %v", i, e, expected)
+			}
+		}
+
+		heap.Push(1)
+		if heap.Top() != 1 || (size > 0 && drained[0] != size-1) {
+			t.Fatalf("Push() after Drain() affects the drained slice")
+		}
+	}
+}
+
+func TestBinaryHeap_Sorted(t *testing.T) {
+	for size := 0; size < 128; size++ {
+		heap := Heapify(rand.Perm(size), func(a, b int) int { return a - b })
+		sorted := heap.Sorted()
+
+		if heap.Len() != size {
+			t.Fatalf("Sorted(), Len() = %v, want %v", heap.Len(), size)
+		}
+		checkHeapProperty(heap, t)
+		for i, e := range sorted {
+			if expected := size - 1 - i; e != expected {
+				t.Fatalf("Sorted()[%d] = %v, want %v", i, e, expected)
+			}
+		}
+	}
+}
+
 func TestBinaryHeap_Top(t *testing.T) {
 	slice := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
 	cmp := func(a, b int) int { return a - b }
@@ -218,6 +391,25 @@ func BenchmarkBinaryHeap_Push_Big(b *testing.B) {
 	}
 }
 
+func benchmarkDaryHeapPush[D Arity](b *testing.B) {
+	heap := NewDary[D](func(l, r int64) int { return int(l - r) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+}
+
+// BenchmarkDaryHeap_Push_Small_4 	68694658	        32.31 ns/op	      42 B/op	       0 allocs/op
+func BenchmarkDaryHeap_Push_Small_4(b *testing.B) {
+	benchmarkDaryHeapPush[Arity4](b)
+}
+
+// BenchmarkDaryHeap_Push_Small_8 	45014502	        25.71 ns/op	      41 B/op	       0 allocs/op
+func BenchmarkDaryHeap_Push_Small_8(b *testing.B) {
+	benchmarkDaryHeapPush[Arity8](b)
+}
+
 // BenchmarkBinaryHeap_Pop_Small-16    	 5932274	       210.9 ns/op	       0 B/op	       0 allocs/op
 func BenchmarkBinaryHeap_Pop_Small(b *testing.B) {
 	heap := BinaryHeap[int64]{cmp: func(l, r int64) int { return int(l - r) }}
@@ -248,6 +440,63 @@ func BenchmarkBinaryHeap_Pop_Big(b *testing.B) {
 	}
 }
 
+func benchmarkDaryHeapPop[D Arity](b *testing.B) {
+	heap := NewDary[D](func(l, r int64) int { return int(l - r) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Pop()
+	}
+}
+
+// BenchmarkDaryHeap_Pop_Small_4  	 4521490	       351.9 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkDaryHeap_Pop_Small_4(b *testing.B) {
+	benchmarkDaryHeapPop[Arity4](b)
+}
+
+// BenchmarkDaryHeap_Pop_Small_8  	 3109280	       389.5 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkDaryHeap_Pop_Small_8(b *testing.B) {
+	benchmarkDaryHeapPop[Arity8](b)
+}
+
+// BenchmarkBinaryHeap_Iterator    	 2862103	       560.1 ns/op	      11 B/op	       0 allocs/op
+func BenchmarkBinaryHeap_Iterator(b *testing.B) {
+	heap := New(func(l, r int64) int { return int(l - r) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	for iter := heap.Begin(); iter.HasNext(); iter.Next() {
+		iter.Get()
+	}
+}
+
+// BenchmarkBinaryHeap_Sorted    	 4461505	       372.3 ns/op	       8 B/op	       0 allocs/op
+func BenchmarkBinaryHeap_Sorted(b *testing.B) {
+	heap := New(func(l, r int64) int { return int(l - r) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	heap.Sorted()
+}
+
+// BenchmarkBinaryHeap_Drain    	 4096666	       368.6 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkBinaryHeap_Drain(b *testing.B) {
+	heap := New(func(l, r int64) int { return int(l - r) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	heap.Drain()
+}
+
 func ExampleNew() {
 	// Create a max heap
 	slice := []int{4, 2, 7, 1, 9, 5}
@@ -336,3 +585,62 @@ func ExampleIterator() {
 	// 2
 	// 1
 }
+
+func ExampleBinaryHeap_PushPop() {
+	// Push an element then pop the top in a single sift
+	slice := []int{4, 2, 7, 1, 9, 5}
+	maxHeap := Heapify(slice, func(i, j int) int { return i - j })
+	fmt.Println(maxHeap.PushPop(8))
+	fmt.Println(maxHeap.PushPop(10))
+	fmt.Println(maxHeap.Top())
+	// Output:
+	// 9
+	// 10
+	// 8
+}
+
+func ExampleBinaryHeap_Fix() {
+	// Restore the heap after changing an element in place
+	slice := []int{4, 2, 7, 1, 9, 5}
+	maxHeap := Heapify(slice, func(i, j int) int { return i - j })
+	slice[3] = 100
+	maxHeap.Fix(3)
+	fmt.Println(maxHeap.Top())
+	// Output: 100
+}
+
+func ExampleBinaryHeap_Drain() {
+	// Consume the heap in priority order
+	slice := []int{4, 2, 7, 1, 9, 5}
+	maxHeap := Heapify(slice, func(i, j int) int { return i - j })
+	fmt.Println(maxHeap.Drain())
+	fmt.Println(maxHeap.Len())
+	// Output:
+	// [9 7 5 4 2 1]
+	// 0
+}
+
+func ExampleBinaryHeap_Sorted() {
+	// Take a sorted snapshot of the heap
+	slice := []int{4, 2, 7, 1, 9, 5}
+	maxHeap := Heapify(slice, func(i, j int) int { return i - j })
+	fmt.Println(maxHeap.Sorted())
+	fmt.Println(maxHeap.Len())
+	// Output:
+	// [9 7 5 4 2 1]
+	// 6
+}
+
+func ExampleBinaryHeap_ShrinkToFit() {
+	// Release the memory after a spike
+	maxHeap := NewWithCapacity(1000, func(i, j int) int { return i - j })
+	for i := 0; i < 1000; i++ {
+		maxHeap.Push(i)
+	}
+	for i := 0; i < 990; i++ {
+		maxHeap.Pop()
+	}
+	maxHeap.ShrinkToFit()
+	fmt.Println(maxHeap.Len(), maxHeap.Cap())
+	// Output: 10 10
+}
@@ -0,0 +1,71 @@
+package binary_heap
+
+import "github.com/evanhyd/sgl/adt"
+
+// The branching factor of a DaryHeap.
+type Arity interface {
+	Arity4 | Arity8
+}
+
+// Four children per node.
+type Arity4 [4]struct{}
+
+// Eight children per node.
+type Arity8 [8]struct{}
+
+// A max d-ary heap, D is the number of children per node.
+//
+// A wider node shortens the sift up, which makes push cheaper than the binary heap,
+// at the cost of more comparisons per level in pop.
+//
+// interface: PriorityQueue
+type DaryHeap[T any, D Arity] struct {
+	slice []T
+	cmp   func(T, T) int
+}
+
+var _ adt.PriorityQueue[int] = &DaryHeap[int, Arity4]{}
+
+// Create a d-ary heap, such as NewDary[Arity4](predicate).
+func NewDary[D Arity, T any](predicate func(T, T) int) DaryHeap[T, D] {
+	return DaryHeap[T, D]{cmp: predicate}
+}
+
+// Heapify the slice into a d-ary heap using cmp as predicate.
+func HeapifyDary[D Arity, T any](slice []T, cmp func(T, T) int) DaryHeap[T, D] {
+	heapify[T, D](slice, cmp)
+	return DaryHeap[T, D]{slice, cmp}
+}
+
+// Return the number of element.
+func (h *DaryHeap[T, D]) Len() int {
+	return len(h.slice)
+}
+
+// Return the capacity.
+func (h *DaryHeap[T, D]) Cap() int {
+	return cap(h.slice)
+}
+
+// Add e to the heap.
+func (h *DaryHeap[T, D]) Push(e T) {
+	h.slice = append(h.slice, e)
+	siftUp[T, D](h.slice, h.cmp, len(h.slice)-1)
+}
+
+// Remove and return the top element from the heap.
+func (h *DaryHeap[T, D]) Pop() T {
+	top := h.slice[0]
+	last := len(h.slice) - 1
+	h.slice[0] = h.slice[last]
+	var zero T
+	h.slice[last] = zero
+	h.slice = h.slice[:last]
+	siftDown[T, D](h.slice, h.cmp, 0)
+	return top
+}
+
+// Return the top of the heap.
+func (h *DaryHeap[T, D]) Top() T {
+	return h.slice[0]
+}
@@ -0,0 +1,71 @@
+package binary_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func checkDaryHeap[T any, D Arity](heap DaryHeap[T, D], t *testing.T) {
+	var d D
+	for i := 1; i < heap.Len(); i++ {
+		if p := (i - 1) / len(d); heap.cmp(heap.slice[i], heap.slice[p]) > 0 {
+			t.Fatalf("Heap property violated at index %d and %d", p, i)
+		}
+	}
+}
+
+func testDaryHeap[D Arity](t *testing.T) {
+	const size = 1 << 9
+	heap := NewDary[D](func(a, b int) int { return a - b })
+	for _, e := range rand.Perm(size) {
+		heap.Push(e)
+		checkDaryHeap(heap, t)
+	}
+
+	for i := 0; i < size; i++ {
+		if expected := size - 1 - i; heap.Top() != expected {
+			t.Fatalf("Top() = %d, want %d", heap.Top(), expected)
+		}
+		if e := heap.Pop(); e != size-1-i {
+			t.Fatalf("Pop() = %d, want %d", e, size-1-i)
+		}
+		checkDaryHeap(heap, t)
+	}
+}
+
+func TestDaryHeap(t *testing.T) {
+	t.Run("4", testDaryHeap[Arity4])
+	t.Run("8", testDaryHeap[Arity8])
+}
+
+func testHeapifyDary[D Arity](t *testing.T) {
+	for size := 0; size < 64; size++ {
+		heap := HeapifyDary[D](rand.Perm(size), func(a, b int) int { return a - b })
+		if heap.Len() != size {
+			t.Fatalf("Len() = %d, want %d", heap.Len(), size)
+		}
+		checkDaryHeap(heap, t)
+	}
+}
+
+func TestHeapifyDary(t *testing.T) {
+	t.Run("4", testHeapifyDary[Arity4])
+	t.Run("8", testHeapifyDary[Arity8])
+}
+
+func ExampleHeapifyDary() {
+	// Create a max 4-ary heap from a slice
+	slice := []int{4, 2, 7, 1, 9, 5}
+	maxHeap := HeapifyDary[Arity4](slice, func(i, j int) int { return i - j })
+	for maxHeap.Len() > 0 {
+		fmt.Println(maxHeap.Pop())
+	}
+	// Output:
+	// 9
+	// 7
+	// 5
+	// 4
+	// 2
+	// 1
+}
@@ -0,0 +1,133 @@
+package binary_heap
+
+import "github.com/evanhyd/sgl/adt"
+
+// A handle to an element in an IndexedHeap.
+//
+// It stays valid until the element is popped or removed.
+type Handle[T any] struct {
+	value T
+	index int
+}
+
+// Return the value.
+func (h *Handle[T]) Get() T {
+	return h.value
+}
+
+// A max binary heap that supports updating and removing elements by handle.
+//
+// It keeps its own sift loops instead of reusing BinaryHeap's, because every swap
+// also updates the index of the moved handles, which the plain heap should not pay for.
+//
+// interface: PriorityQueue
+type IndexedHeap[T any] struct {
+	slice []*Handle[T]
+	cmp   func(T, T) int
+}
+
+var _ adt.PriorityQueue[int] = &IndexedHeap[int]{}
+
+func NewIndexed[T any](predicate func(T, T) int) IndexedHeap[T] {
+	return IndexedHeap[T]{cmp: predicate}
+}
+
+// Return the number of element.
+func (h *IndexedHeap[T]) Len() int {
+	return len(h.slice)
+}
+
+// Add e to the heap.
+func (h *IndexedHeap[T]) Push(e T) {
+	h.PushHandle(e)
+}
+
+// Add e to the heap, return a handle to it.
+func (h *IndexedHeap[T]) PushHandle(e T) *Handle[T] {
+	handle := &Handle[T]{e, len(h.slice)}
+	h.slice = append(h.slice, handle)
+	h.fixUp(handle.index)
+	return handle
+}
+
+// Remove and return the top element from the heap.
+func (h *IndexedHeap[T]) Pop() T {
+	top := h.slice[0]
+	h.Remove(top)
+	return top.value
+}
+
+// Return the top of the heap.
+func (h *IndexedHeap[T]) Top() T {
+	return h.slice[0].value
+}
+
+// Change the value of the element, and restore the heap property in Θ(log n).
+func (h *IndexedHeap[T]) Update(handle *Handle[T], e T) {
+	h.validate(handle)
+	handle.value = e
+	h.fixUp(handle.index)
+	h.fixDown(handle.index)
+}
+
+// Remove the element from the heap in Θ(log n).
+func (h *IndexedHeap[T]) Remove(handle *Handle[T]) {
+	h.validate(handle)
+	i, last := handle.index, len(h.slice)-1
+	h.swap(i, last)
+	h.slice[last] = nil
+	h.slice = h.slice[:last]
+	handle.index = -1
+
+	if i < last {
+		h.fixUp(i)
+		h.fixDown(i)
+	}
+}
+
+// Panic if the handle does not belong to the heap.
+func (h *IndexedHeap[T]) validate(handle *Handle[T]) {
+	if handle.index < 0 || handle.index >= len(h.slice) || h.slice[handle.index] != handle {
+		panic("binary_heap: handle is not in the heap")
+	}
+}
+
+// Swap the elements at i and j, and update their indexes.
+func (h *IndexedHeap[T]) swap(i int, j int) {
+	h.slice[i], h.slice[j] = h.slice[j], h.slice[i]
+	h.slice[i].index = i
+	h.slice[j].index = j
+}
+
+// Fix the heap property start from child upward.
+func (h *IndexedHeap[T]) fixUp(child int) {
+	for i := child; i > 0; {
+		p := (i - 1) / 2
+		if h.cmp(h.slice[i].value, h.slice[p].value) > 0 {
+			h.swap(i, p)
+			i = p
+		} else {
+			break
+		}
+	}
+}
+
+// Fix the heap property rooted at root downward.
+func (h *IndexedHeap[T]) fixDown(root int) {
+	for end := len(h.slice) / 2; root < end; {
+		child := root*2 + 1
+
+		if r := child + 1; r < len(h.slice) {
+			if h.cmp(h.slice[r].value, h.slice[child].value) > 0 {
+				child = r
+			}
+		}
+
+		if h.cmp(h.slice[child].value, h.slice[root].value) > 0 {
+			h.swap(root, child)
+			root = child
+		} else {
+			break
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package binary_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func checkIndexedHeap[T any](heap IndexedHeap[T], t *testing.T) {
+	for i, handle := range heap.slice {
+		if handle.index != i {
+			t.Fatalf("index of element %d is %d", i, handle.index)
+		}
+		if p := (i - 1) / 2; i > 0 && heap.cmp(heap.slice[i].value, heap.slice[p].value) > 0 {
+			t.Fatalf("Heap property violated at index %d and %d", p, i)
+		}
+	}
+}
+
+func TestIndexedHeap(t *testing.T) {
+	const testSize = 1 << 9
+	heap := NewIndexed(func(a, b int) int { return a - b })
+	handles := []*Handle[int]{}
+
+	for i := 0; i < 4*testSize; i++ {
+		switch op := rand.Intn(5); {
+		case op < 2 || len(handles) == 0:
+			handles = append(handles, heap.PushHandle(rand.Intn(testSize)))
+		case op == 2:
+			j := rand.Intn(len(handles))
+			heap.Remove(handles[j])
+			handles[j] = handles[len(handles)-1]
+			handles = handles[:len(handles)-1]
+		case op == 3:
+			handle := handles[rand.Intn(len(handles))]
+			heap.Update(handle, rand.Intn(testSize))
+		default:
+			top := heap.Top()
+			heap.Pop()
+			for j, handle := range handles {
+				if handle.index < 0 {
+					if handle.Get() != top {
+						t.Fatalf("Pop() removes %d, want %d", handle.Get(), top)
+					}
+					handles[j] = handles[len(handles)-1]
+					handles = handles[:len(handles)-1]
+					break
+				}
+			}
+		}
+
+		if heap.Len() != len(handles) {
+			t.Fatalf("Len() = %d, want %d", heap.Len(), len(handles))
+		}
+		checkIndexedHeap(heap, t)
+
+		maxValue := -1
+		for _, handle := range handles {
+			maxValue = max(maxValue, handle.Get())
+		}
+		if heap.Len() > 0 && heap.Top() != maxValue {
+			t.Fatalf("Top() = %d, want %d", heap.Top(), maxValue)
+		}
+	}
+}
+
+func TestIndexedHeap_InvalidHandle(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Remove() with a removed handle does not panic")
+		}
+	}()
+
+	heap := NewIndexed(func(a, b int) int { return a - b })
+	handle := heap.PushHandle(1)
+	heap.Push(2)
+	heap.Remove(handle)
+	heap.Remove(handle)
+}
+
+// BenchmarkIndexedHeap_Update    	15382000	        97.46 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkIndexedHeap_Update(b *testing.B) {
+	heap := NewIndexed(func(l, r int64) int { return int(l - r) })
+	handles := make([]*Handle[int64], 1<<16)
+	for i := range handles {
+		handles[i] = heap.PushHandle(int64(i))
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		handle := handles[i%len(handles)]
+		heap.Update(handle, handle.Get()+int64(len(handles)))
+	}
+}
+
+func ExampleIndexedHeap() {
+	// Promote a task by its handle
+	heap := NewIndexed(func(i, j int) int { return i - j })
+	heap.Push(5)
+	task := heap.PushHandle(1)
+	heap.Push(3)
+
+	heap.Update(task, 10)
+	fmt.Println(heap.Top())
+	heap.Remove(task)
+	fmt.Println(heap.Top())
+	// Output:
+	// 10
+	// 5
+}
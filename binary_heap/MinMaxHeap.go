@@ -0,0 +1,180 @@
+package binary_heap
+
+import (
+	"math/bits"
+
+	"github.com/evanhyd/sgl/adt"
+)
+
+// A min-max heap, a double-ended priority queue.
+//
+// The levels alternate between max levels and min levels, starting with a max level at the root.
+// Every element on a max level is the greatest of its subtree, and every element on a min level is the least.
+//
+// interface: PriorityQueue
+type MinMaxHeap[T any] struct {
+	slice []T
+	cmp   func(T, T) int
+}
+
+var _ adt.PriorityQueue[int] = &MinMaxHeap[int]{}
+
+func NewMinMax[T any](predicate func(T, T) int) MinMaxHeap[T] {
+	return MinMaxHeap[T]{cmp: predicate}
+}
+
+// Heapify the slice into a min-max heap using cmp as predicate.
+func HeapifyMinMax[T any](slice []T, cmp func(T, T) int) MinMaxHeap[T] {
+	heap := MinMaxHeap[T]{slice, cmp}
+	for i := len(heap.slice)/2 - 1; i >= 0; i-- {
+		heap.fixDown(i)
+	}
+	return heap
+}
+
+// Return the number of element.
+func (m *MinMaxHeap[T]) Len() int {
+	return len(m.slice)
+}
+
+// Return the capacity.
+func (m *MinMaxHeap[T]) Cap() int {
+	return cap(m.slice)
+}
+
+// Add e to the heap.
+func (m *MinMaxHeap[T]) Push(e T) {
+	m.slice = append(m.slice, e)
+	m.fixUp(len(m.slice) - 1)
+}
+
+// Remove and return the top (max) element from the heap.
+func (m *MinMaxHeap[T]) Pop() T {
+	return m.remove(0)
+}
+
+// Return the top (max) of the heap.
+func (m *MinMaxHeap[T]) Top() T {
+	return m.slice[0]
+}
+
+// Remove and return the bottom (min) element from the heap.
+func (m *MinMaxHeap[T]) PopBottom() T {
+	return m.remove(m.bottom())
+}
+
+// Return the bottom (min) of the heap.
+func (m *MinMaxHeap[T]) Bottom() T {
+	return m.slice[m.bottom()]
+}
+
+// Return the index of the bottom, which is the least of the root and its children.
+func (m *MinMaxHeap[T]) bottom() int {
+	switch len(m.slice) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if m.cmp(m.slice[2], m.slice[1]) < 0 {
+		return 2
+	}
+	return 1
+}
+
+// Remove and return the element at i by replacing it with the last element.
+func (m *MinMaxHeap[T]) remove(i int) T {
+	e := m.slice[i]
+	last := len(m.slice) - 1
+	m.slice[i] = m.slice[last]
+	var zero T
+	m.slice[last] = zero
+	m.slice = m.slice[:last]
+	if i < last {
+		m.fixDown(i)
+	}
+	return e
+}
+
+// Return true if i is on a max level.
+func (m *MinMaxHeap[T]) isMaxLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// Return true if the element at i should be above the element at j on a max level (or min level if not max).
+func (m *MinMaxHeap[T]) before(i int, j int, max bool) bool {
+	if max {
+		return m.cmp(m.slice[i], m.slice[j]) > 0
+	}
+	return m.cmp(m.slice[i], m.slice[j]) < 0
+}
+
+// Fix the heap property start from child upward.
+func (m *MinMaxHeap[T]) fixUp(child int) {
+	if child == 0 {
+		return
+	}
+
+	//move to the parent level if it belongs there, then bubble up the levels of the same kind
+	max := m.isMaxLevel(child)
+	if p := m.parent(child); m.before(child, p, !max) {
+		m.slice[child], m.slice[p] = m.slice[p], m.slice[child]
+		child, max = p, !max
+	}
+
+	for i := child; i > 2; {
+		g := m.parent(m.parent(i))
+		if m.before(i, g, max) {
+			m.slice[i], m.slice[g] = m.slice[g], m.slice[i]
+			i = g
+		} else {
+			break
+		}
+	}
+}
+
+// Fix the heap property rooted at root downward.
+func (m *MinMaxHeap[T]) fixDown(root int) {
+	max := m.isMaxLevel(root)
+	for {
+		//find the best among the children and grandchildren
+		first := m.left(root)
+		if first >= len(m.slice) {
+			break
+		}
+
+		best := first
+		if r := first + 1; r < len(m.slice) && m.before(r, best, max) {
+			best = r
+		}
+		for g, end := m.left(first), min(m.left(first)+4, len(m.slice)); g < end; g++ {
+			if m.before(g, best, max) {
+				best = g
+			}
+		}
+
+		if !m.before(best, root, max) {
+			break
+		}
+		m.slice[root], m.slice[best] = m.slice[best], m.slice[root]
+		if best <= first+1 {
+			break
+		}
+
+		//the grandchild may be out of order with its parent on the opposite level
+		if p := m.parent(best); m.before(p, best, max) {
+			m.slice[best], m.slice[p] = m.slice[p], m.slice[best]
+		}
+		root = best
+	}
+}
+
+// Return the parent of i.
+func (m *MinMaxHeap[T]) parent(i int) int {
+	return (i - 1) / 2
+}
+
+// Return the left child of i.
+func (m *MinMaxHeap[T]) left(i int) int {
+	return i*2 + 1
+}
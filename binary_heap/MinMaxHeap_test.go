@@ -0,0 +1,114 @@
+package binary_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func checkMinMaxHeap[T any](heap MinMaxHeap[T], t *testing.T) {
+	for i := 1; i < heap.Len(); i++ {
+		for a := heap.parent(i); ; a = heap.parent(a) {
+			if cmp := heap.cmp(heap.slice[a], heap.slice[i]); (heap.isMaxLevel(a) && cmp < 0) || (!heap.isMaxLevel(a) && cmp > 0) {
+				t.Fatalf("Heap property violated at index %d and %d", a, i)
+			}
+			if a == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestMinMaxHeap(t *testing.T) {
+	const size = 1 << 9
+	heap := NewMinMax(func(a, b int) int { return a - b })
+	sHeap := map[int]int{}
+	lo, hi := size, -1
+
+	for i := 0; i < 4*size; i++ {
+		if heap.Len() == 0 || rand.Intn(3) > 0 {
+			e := rand.Intn(size)
+			heap.Push(e)
+			sHeap[e]++
+		} else if rand.Intn(2) == 0 {
+			sHeap[heap.Top()]--
+			heap.Pop()
+		} else {
+			sHeap[heap.Bottom()]--
+			heap.PopBottom()
+		}
+		checkMinMaxHeap(heap, t)
+
+		lo, hi = size, -1
+		for e, count := range sHeap {
+			if count > 0 {
+				lo, hi = min(lo, e), max(hi, e)
+			}
+		}
+		if heap.Len() > 0 && (heap.Top() != hi || heap.Bottom() != lo) {
+			t.Fatalf("(Top(), Bottom()) = (%d, %d), want (%d, %d)", heap.Top(), heap.Bottom(), hi, lo)
+		}
+	}
+}
+
+func TestHeapifyMinMax(t *testing.T) {
+	for size := 0; size < 128; size++ {
+		heap := HeapifyMinMax(rand.Perm(size), func(a, b int) int { return a - b })
+		checkMinMaxHeap(heap, t)
+
+		for i := 0; i < size; i++ {
+			if i%2 == 0 {
+				if expected := size - 1 - i/2; heap.Top() != expected {
+					t.Fatalf("Top() = %d, want %d", heap.Top(), expected)
+				}
+				heap.Pop()
+			} else {
+				if expected := i / 2; heap.Bottom() != expected {
+					t.Fatalf("Bottom() = %d, want %d", heap.Bottom(), expected)
+				}
+				heap.PopBottom()
+			}
+			checkMinMaxHeap(heap, t)
+		}
+	}
+}
+
+// BenchmarkMinMaxHeap_Push_Small    	25220683	        42.62 ns/op	      47 B/op	       0 allocs/op
+func BenchmarkMinMaxHeap_Push_Small(b *testing.B) {
+	heap := NewMinMax(func(l, r int64) int { return int(l - r) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+}
+
+// BenchmarkMinMaxHeap_Pop_Small    	 1884297	       753.8 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkMinMaxHeap_Pop_Small(b *testing.B) {
+	heap := NewMinMax(func(l, r int64) int { return int(l - r) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			heap.Pop()
+		} else {
+			heap.PopBottom()
+		}
+	}
+}
+
+func ExampleMinMaxHeap() {
+	// Access both ends of the heap
+	slice := []int{4, 2, 7, 1, 9, 5}
+	heap := HeapifyMinMax(slice, func(i, j int) int { return i - j })
+	fmt.Println(heap.Top(), heap.Bottom())
+	heap.Pop()
+	heap.PopBottom()
+	fmt.Println(heap.Top(), heap.Bottom())
+	// Output:
+	// 9 1
+	// 7 2
+}
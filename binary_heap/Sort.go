@@ -0,0 +1,58 @@
+package binary_heap
+
+// Sort the slice in ascending order using cmp as predicate.
+//
+// It sorts in place with Θ(1) extra memory in Θ(n log n), the sort is not stable.
+func Sort[T any](slice []T, cmp func(T, T) int) {
+	heap := Heapify(slice, cmp)
+	heap.sortDown()
+}
+
+// Rearrange the slice so that slice[:k] holds the k least elements in ascending order.
+//
+// The order of the rest is unspecified. It runs in Θ(n log k).
+func PartialSort[T any](slice []T, k int, cmp func(T, T) int) {
+	k = min(k, len(slice))
+	if k <= 0 {
+		return
+	}
+
+	heap := heapSelect(slice, k, cmp)
+	heap.sortDown()
+}
+
+// Rearrange the slice so that slice[n] is the element that would be there if sorted.
+//
+// Every element before n is less than or equal to it, and every element after n is greater than or equal to it.
+// It runs in Θ(len(slice) log n).
+func NthElement[T any](slice []T, n int, cmp func(T, T) int) {
+	if n < 0 || n >= len(slice) {
+		panic("binary_heap: NthElement index out of range")
+	}
+
+	heapSelect(slice, n+1, cmp)
+	slice[0], slice[n] = slice[n], slice[0]
+}
+
+// Collect the k least elements into a max heap at slice[:k].
+func heapSelect[T any](slice []T, k int, cmp func(T, T) int) BinaryHeap[T] {
+	heap := Heapify(slice[:k], cmp)
+	for i := k; i < len(slice); i++ {
+		if cmp(slice[i], heap.slice[0]) < 0 {
+			slice[i], heap.slice[0] = heap.slice[0], slice[i]
+			heap.fixDown(0)
+		}
+	}
+	return heap
+}
+
+// Move the top to the back repeatedly, which leaves the underlying array in ascending order.
+//
+// The heap is left with at most one element.
+func (b *BinaryHeap[T]) sortDown() {
+	for end := len(b.slice) - 1; end > 0; end-- {
+		b.slice[0], b.slice[end] = b.slice[end], b.slice[0]
+		b.slice = b.slice[:end]
+		b.fixDown(0)
+	}
+}
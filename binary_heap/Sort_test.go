@@ -0,0 +1,104 @@
+package binary_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	for size := 0; size < 128; size++ {
+		slice := make([]int, size)
+		for i := range slice {
+			slice[i] = rand.Intn(size/2 + 1)
+		}
+		expected := append([]int{}, slice...)
+		sort.Ints(expected)
+
+		Sort(slice, cmp)
+		if fmt.Sprint(slice) != fmt.Sprint(expected) {
+			t.Fatalf("Sort() = %v, want %v", slice, expected)
+		}
+	}
+}
+
+func TestPartialSort(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	for size := 0; size < 64; size++ {
+		for k := -1; k <= size+1; k++ {
+			slice := rand.Perm(size)
+			PartialSort(slice, k, cmp)
+
+			for i := 0; i < min(max(k, 0), size); i++ {
+				if slice[i] != i {
+					t.Fatalf("PartialSort(%d) of size %d = %v", k, size, slice)
+				}
+			}
+		}
+	}
+}
+
+func TestNthElement(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	for size := 1; size < 64; size++ {
+		for n := 0; n < size; n++ {
+			slice := make([]int, size)
+			for i := range slice {
+				slice[i] = rand.Intn(size/2 + 1)
+			}
+			expected := append([]int{}, slice...)
+			sort.Ints(expected)
+
+			NthElement(slice, n, cmp)
+			if slice[n] != expected[n] {
+				t.Fatalf("NthElement(%d) = %d, want %d", n, slice[n], expected[n])
+			}
+			for i := range slice {
+				if (i < n && slice[i] > slice[n]) || (i > n && slice[i] < slice[n]) {
+					t.Fatalf("NthElement(%d) = %v, not partitioned at %d", n, slice, i)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkSort    	 2870956	       472.7 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkSort(b *testing.B) {
+	slice := make([]int64, b.N)
+	for i := range slice {
+		slice[i] = rand.Int63()
+	}
+	b.ResetTimer()
+
+	Sort(slice, func(l, r int64) int {
+		if l < r {
+			return -1
+		} else if l > r {
+			return 1
+		}
+		return 0
+	})
+}
+
+func ExampleSort() {
+	slice := []int{4, 2, 7, 1, 9, 5}
+	Sort(slice, func(i, j int) int { return i - j })
+	fmt.Println(slice)
+	// Output: [1 2 4 5 7 9]
+}
+
+func ExamplePartialSort() {
+	slice := []int{4, 2, 7, 1, 9, 5}
+	PartialSort(slice, 3, func(i, j int) int { return i - j })
+	fmt.Println(slice[:3])
+	// Output: [1 2 4]
+}
+
+func ExampleNthElement() {
+	slice := []int{4, 2, 7, 1, 9, 5}
+	NthElement(slice, 2, func(i, j int) int { return i - j })
+	fmt.Println(slice[2])
+	// Output: 4
+}
@@ -0,0 +1,44 @@
+package binary_heap
+
+// A collector that keeps the k greatest elements seen so far.
+type TopK[T any] struct {
+	heap BinaryHeap[T]
+	k    int
+}
+
+// Create a collector of the k greatest elements using predicate, k must not be negative.
+func NewTopK[T any](k int, predicate func(T, T) int) TopK[T] {
+	if k < 0 {
+		panic("binary_heap: TopK requires k >= 0")
+	}
+
+	//the inverse predicate keeps the least collected element on the top
+	heap := New(func(a, b T) int { return predicate(b, a) })
+	heap.slice = make([]T, 0, k)
+	return TopK[T]{heap, k}
+}
+
+// Return the number of element collected.
+func (t *TopK[T]) Len() int {
+	return t.heap.Len()
+}
+
+// Offer e to the collector.
+//
+// Once k elements are collected, e replaces the least one only if it is greater, in a single sift.
+func (t *TopK[T]) Push(e T) {
+	if t.heap.Len() < t.k {
+		t.heap.Push(e)
+	} else if t.k > 0 && t.heap.cmp(e, t.heap.Top()) < 0 {
+		t.heap.Replace(e)
+	}
+}
+
+// Return the collected elements sorted from the greatest to the least.
+func (t *TopK[T]) Result() []T {
+	sorted := make([]T, t.heap.Len())
+	copy(sorted, t.heap.slice)
+	result := BinaryHeap[T]{slice: sorted, cmp: t.heap.cmp} //the copy is already a heap
+	result.sortDown()
+	return sorted
+}
@@ -0,0 +1,64 @@
+package binary_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	const size = 1 << 10
+	for _, k := range []int{0, 1, 7, 100, size, 2 * size} {
+		topK := NewTopK(k, func(a, b int) int { return a - b })
+		stream := make([]int, size)
+		for i := range stream {
+			stream[i] = rand.Intn(size / 2)
+			topK.Push(stream[i])
+		}
+
+		sort.Sort(sort.Reverse(sort.IntSlice(stream)))
+		expected := stream[:min(k, size)]
+		if topK.Len() != len(expected) {
+			t.Fatalf("k = %d, Len() = %d, want %d", k, topK.Len(), len(expected))
+		}
+		if actual := topK.Result(); fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Fatalf("k = %d, Result() = %v, want %v", k, actual, expected)
+		}
+
+		//the result does not consume the collector
+		if topK.Len() != len(expected) {
+			t.Fatalf("k = %d, Len() after Result() = %d, want %d", k, topK.Len(), len(expected))
+		}
+	}
+}
+
+func TestTopK_NegativeK(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("NewTopK() with a negative k does not panic")
+		}
+	}()
+
+	NewTopK(-1, func(a, b int) int { return a - b })
+}
+
+// BenchmarkTopK_Push    	121940056	         9.987 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkTopK_Push(b *testing.B) {
+	topK := NewTopK(100, func(l, r int64) int { return int(l - r) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		topK.Push(int64(i % (b.N/100 + 1)))
+	}
+}
+
+func ExampleTopK() {
+	// Keep the 3 greatest elements of a stream
+	topK := NewTopK(3, func(i, j int) int { return i - j })
+	for _, n := range []int{4, 2, 7, 1, 9, 5, 8} {
+		topK.Push(n)
+	}
+	fmt.Println(topK.Result())
+	// Output: [9 8 7]
+}
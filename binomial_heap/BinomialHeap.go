@@ -2,14 +2,33 @@ package binomial_heap
 
 import (
 	"math/bits"
+	"slices"
 
 	"github.com/evanhyd/sgl/adt"
 )
 
+// A binomial tree in half-ordered binary tree representation.
+//
+// left points to the first child, right points to the next sibling.
+// The key is greater than or equal to every key in the left subtree.
 type flagTree[T any] struct {
-	key   T
-	left  *flagTree[T]
-	right *flagTree[T]
+	key    T
+	left   *flagTree[T]
+	right  *flagTree[T]
+	parent *flagTree[T]
+	handle *Handle[T]
+}
+
+// A handle to an element in a BinomialHeap.
+//
+// It stays valid until the element is popped or deleted.
+type Handle[T any] struct {
+	tree *flagTree[T]
+}
+
+// Return the value.
+func (h *Handle[T]) Get() T {
+	return h.tree.key
 }
 
 // A max binomial heap.
@@ -36,25 +55,39 @@ func (b *BinomialHeap[T]) Len() int {
 
 // Push e to the heap.
 func (b *BinomialHeap[T]) Push(e T) {
-	b.len++
-	b.reserve()
-	b.mergeTree(&flagTree[T]{e, nil, nil}, 0)
+	b.push(&flagTree[T]{key: e})
 }
 
-// Remove the top element from the heap.
-func (b *BinomialHeap[T]) Pop() {
-	b.len--
-	height := b.max()
-	tree := b.trees[height].left
-	b.trees[height] = nil
+// Push e to the heap, return a handle to it.
+func (b *BinomialHeap[T]) PushHandle(e T) *Handle[T] {
+	tree := &flagTree[T]{key: e}
+	tree.handle = &Handle[T]{tree}
+	b.push(tree)
+	return tree.handle
+}
 
-	//split the flag tree into smaller flag trees
-	for height--; height >= 0; height-- {
-		subTree := tree.right
-		tree.right = nil
-		b.mergeTree(tree, height)
-		tree = subTree
+// Remove and return the top element from the heap.
+func (b *BinomialHeap[T]) Pop() T {
+	return b.popTree(b.max())
+}
+
+// Increase the value of the element to e in Θ(log n).
+//
+// It panics if e is less than the current value.
+func (b *BinomialHeap[T]) IncreaseKey(handle *Handle[T], e T) {
+	b.validate(handle)
+	if b.cmp(e, handle.tree.key) < 0 {
+		panic("binomial_heap: IncreaseKey with a lesser value")
 	}
+	handle.tree.key = e
+	b.siftUp(handle.tree, false)
+}
+
+// Remove the element from the heap in Θ(log n).
+func (b *BinomialHeap[T]) Delete(handle *Handle[T]) {
+	height := b.validate(handle)
+	b.siftUp(handle.tree, true)
+	b.popTree(height)
 }
 
 // Return the top of the heap.
@@ -73,6 +106,72 @@ func (b *BinomialHeap[T]) Merge(heap BinomialHeap[T]) {
 	}
 }
 
+// Push the single node tree to the heap.
+func (b *BinomialHeap[T]) push(tree *flagTree[T]) {
+	b.len++
+	b.reserve()
+	b.mergeTree(tree, 0)
+}
+
+// Remove the root of the flag tree indexing by height, return its key.
+func (b *BinomialHeap[T]) popTree(height int) T {
+	b.len--
+	root := b.trees[height]
+	root.handle = nil
+	tree := root.left
+	b.trees[height] = nil
+
+	//split the flag tree into smaller flag trees
+	for height--; height >= 0; height-- {
+		subTree := tree.right
+		tree.right, tree.parent = nil, nil
+		b.mergeTree(tree, height)
+		tree = subTree
+	}
+	return root.key
+}
+
+// Panic if the handle is popped, deleted or from another heap, return the height of its flag tree.
+func (b *BinomialHeap[T]) validate(handle *Handle[T]) int {
+	if handle.tree.handle == handle {
+		root := handle.tree
+		for root.parent != nil {
+			root = root.parent
+		}
+		if height := slices.Index(b.trees, root); height != -1 {
+			return height
+		}
+	}
+	panic("binomial_heap: handle is not in the heap")
+}
+
+// Swap the node with its ancestors as long as it is greater, or all the way up if force is true.
+//
+// Return the node where it stops.
+func (b *BinomialHeap[T]) siftUp(n *flagTree[T], force bool) *flagTree[T] {
+	for {
+		//the nearest ancestor that has n in its left subtree
+		a := n
+		for a.parent != nil && a.parent.right == a {
+			a = a.parent
+		}
+		p := a.parent
+		if p == nil || (!force && b.cmp(n.key, p.key) <= 0) {
+			return n
+		}
+
+		n.key, p.key = p.key, n.key
+		n.handle, p.handle = p.handle, n.handle
+		if n.handle != nil {
+			n.handle.tree = n
+		}
+		if p.handle != nil {
+			p.handle.tree = p
+		}
+		n = p
+	}
+}
+
 // Find the top of the heap, return the index of the flag tree.
 func (b *BinomialHeap[T]) max() int {
 	m := -1
@@ -108,5 +207,9 @@ func (b *BinomialHeap[T]) mergeTree(tree *flagTree[T], height int) {
 			tree, t = t, tree
 		}
 		tree.left, t.right = t, tree.left
+		t.parent = tree
+		if t.right != nil {
+			t.right.parent = t
+		}
 	}
 }
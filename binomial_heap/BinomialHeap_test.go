@@ -2,9 +2,174 @@ package binomial_heap
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 )
 
+func checkFlagTree[T any](heap BinomialHeap[T], tree *flagTree[T], t *testing.T) {
+	if tree.handle != nil && tree.handle.tree != tree {
+		t.Fatalf("handle of %v points to another node", tree.key)
+	}
+	if tree.left != nil {
+		if tree.left.parent != tree {
+			t.Fatalf("parent of left child of %v is wrong", tree.key)
+		}
+		checkHalfOrder(heap, tree.key, tree.left, t)
+		checkFlagTree(heap, tree.left, t)
+	}
+	if tree.right != nil {
+		if tree.right.parent != tree {
+			t.Fatalf("parent of right child of %v is wrong", tree.key)
+		}
+		checkFlagTree(heap, tree.right, t)
+	}
+}
+
+func checkHalfOrder[T any](heap BinomialHeap[T], key T, tree *flagTree[T], t *testing.T) {
+	if tree == nil {
+		return
+	}
+	if heap.cmp(tree.key, key) > 0 {
+		t.Fatalf("Heap property violated at %v and %v", key, tree.key)
+	}
+	checkHalfOrder(heap, key, tree.left, t)
+	checkHalfOrder(heap, key, tree.right, t)
+}
+
+func checkBinomialHeap[T any](heap BinomialHeap[T], t *testing.T) {
+	for _, tree := range heap.trees {
+		if tree != nil {
+			if tree.parent != nil || tree.right != nil {
+				t.Fatalf("root %v has parent or sibling", tree.key)
+			}
+			checkFlagTree(heap, tree, t)
+		}
+	}
+}
+
+func TestBinomialHeap_Handle(t *testing.T) {
+	const testSize = 1 << 9
+	heap := New(func(a, b int) int { return a - b })
+	handles := []*Handle[int]{}
+
+	for i := 0; i < 4*testSize; i++ {
+		switch op := rand.Intn(6); {
+		case op < 2 || heap.Len() == 0:
+			handles = append(handles, heap.PushHandle(rand.Intn(testSize)))
+		case op == 2:
+			j := rand.Intn(len(handles))
+			heap.Delete(handles[j])
+			handles[j] = handles[len(handles)-1]
+			handles = handles[:len(handles)-1]
+		case op == 3:
+			handle := handles[rand.Intn(len(handles))]
+			heap.IncreaseKey(handle, handle.Get()+rand.Intn(testSize))
+		case op == 4:
+			other := New(func(a, b int) int { return a - b })
+			for j := rand.Intn(8); j > 0; j-- {
+				handles = append(handles, other.PushHandle(rand.Intn(testSize)))
+			}
+			heap.Merge(other)
+		default:
+			top := heap.Pop()
+			for j, handle := range handles {
+				if handle.tree.handle != handle {
+					if handle.Get() != top {
+						t.Fatalf("Pop() removes %d, want %d", handle.Get(), top)
+					}
+					handles[j] = handles[len(handles)-1]
+					handles = handles[:len(handles)-1]
+					break
+				}
+			}
+		}
+
+		if heap.Len() != len(handles) {
+			t.Fatalf("Len() = %d, want %d", heap.Len(), len(handles))
+		}
+		checkBinomialHeap(heap, t)
+
+		maxValue := -1
+		for _, handle := range handles {
+			maxValue = max(maxValue, handle.Get())
+		}
+		if heap.Len() > 0 && heap.Top() != maxValue {
+			t.Fatalf("Top() = %d, want %d", heap.Top(), maxValue)
+		}
+	}
+}
+
+func TestBinomialHeap_InvalidHandle(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Delete() with a deleted handle does not panic")
+		}
+	}()
+
+	heap := New(func(a, b int) int { return a - b })
+	handle := heap.PushHandle(1)
+	heap.Push(2)
+	heap.Delete(handle)
+	heap.Delete(handle)
+}
+
+func TestBinomialHeap_ForeignHandle(t *testing.T) {
+	a := New(func(a, b int) int { return a - b })
+	b := New(func(a, b int) int { return a - b })
+	handles := []*Handle[int]{}
+	for i := 0; i < 8; i++ {
+		handles = append(handles, a.PushHandle(i))
+		b.Push(i)
+	}
+
+	tests := []struct {
+		name string
+		call func(*Handle[int])
+	}{
+		{"Delete", func(h *Handle[int]) { b.Delete(h) }},
+		{"IncreaseKey", func(h *Handle[int]) { b.IncreaseKey(h, 100) }},
+	}
+	for _, test := range tests {
+		for _, handle := range handles {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("%s() with a handle of another heap does not panic", test.name)
+					}
+				}()
+				test.call(handle)
+			}()
+		}
+	}
+	if a.Len() != 8 || b.Len() != 8 {
+		t.Fatalf("Len() = (%d, %d), want (8, 8)", a.Len(), b.Len())
+	}
+	checkBinomialHeap(a, t)
+	checkBinomialHeap(b, t)
+
+	//the handles move with the merged heap
+	b.Merge(a)
+	for _, handle := range handles {
+		b.Delete(handle)
+		checkBinomialHeap(b, t)
+	}
+	if b.Len() != 8 {
+		t.Fatalf("Len() = %d, want 8", b.Len())
+	}
+}
+
+func TestBinomialHeap_IncreaseKeyLesser(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("IncreaseKey() with a lesser value does not panic")
+		}
+	}()
+
+	heap := New(func(a, b int) int { return a - b })
+	handle := heap.PushHandle(5)
+	heap.IncreaseKey(handle, 4)
+}
+
 func TestBinomialHeap_Len(t *testing.T) {
 	heap := New(func(a, b int) int { return a - b })
 	// Test Len on an empty heap
@@ -84,7 +249,9 @@ func TestBinomialHeap_Pop(t *testing.T) {
 	heap.Push(5)
 	heap.Push(3)
 	heap.Push(7)
-	heap.Pop()
+	if top := heap.Pop(); top != 7 {
+		t.Errorf("Pop() = %d, want 7", top)
+	}
 	if len := heap.Len(); len != 2 {
 		t.Errorf("Pop(), Len() = %d, want 2", len)
 	}
@@ -239,6 +406,35 @@ func BenchmarkBinomialHeap_Pop_Big(b *testing.B) {
 	}
 }
 
+// BenchmarkBinomialHeap_IncreaseKey 	 7894369	       147.3 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkBinomialHeap_IncreaseKey(b *testing.B) {
+	heap := New(func(a, b int64) int { return int(a - b) })
+	handles := make([]*Handle[int64], b.N)
+	for i := range handles {
+		handles[i] = heap.PushHandle(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	for i, handle := range handles {
+		heap.IncreaseKey(handle, handle.Get()+int64(i%100))
+	}
+}
+
+// BenchmarkBinomialHeap_Delete      	 1000000	      2173 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkBinomialHeap_Delete(b *testing.B) {
+	heap := New(func(a, b int64) int { return int(a - b) })
+	handles := make([]*Handle[int64], b.N)
+	for i := range handles {
+		handles[i] = heap.PushHandle(int64(i % (b.N/100 + 1)))
+	}
+	rand.Shuffle(len(handles), func(i, j int) { handles[i], handles[j] = handles[j], handles[i] })
+	b.ResetTimer()
+
+	for _, handle := range handles {
+		heap.Delete(handle)
+	}
+}
+
 func ExampleBinomialHeap_Len() {
 	heap := New(func(a, b int) int { return a - b })
 	fmt.Println(heap.Len())
@@ -305,3 +501,29 @@ func ExampleBinomialHeap_Merge() {
 	// 4
 	// 10
 }
+
+func ExampleBinomialHeap_IncreaseKey() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	handle := heap.PushHandle(3)
+	heap.Push(7)
+	heap.IncreaseKey(handle, 10)
+	fmt.Println(heap.Top())
+	fmt.Println(handle.Get())
+	// Output:
+	// 10
+	// 10
+}
+
+func ExampleBinomialHeap_Delete() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	heap.Push(3)
+	handle := heap.PushHandle(7)
+	heap.Delete(handle)
+	fmt.Println(heap.Len())
+	fmt.Println(heap.Top())
+	// Output:
+	// 2
+	// 5
+}
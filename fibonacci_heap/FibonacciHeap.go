@@ -0,0 +1,273 @@
+package fibonacci_heap
+
+import (
+	"math/bits"
+
+	"github.com/evanhyd/sgl/adt"
+)
+
+// The degree of a node is at most log_φ(n) < 1.45 * log2(n).
+const maxDegree = bits.UintSize * 3 / 2
+
+// A handle to an element in a FibonacciHeap.
+//
+// It is the node of the heap, siblings are linked in a circular list.
+// It stays valid until the element is popped or deleted.
+type Handle[T any] struct {
+	value  T
+	parent *Handle[T]
+	child  *Handle[T]
+	left   *Handle[T]
+	right  *Handle[T]
+	degree int
+	marked bool
+	owner  *owner
+}
+
+// Return the value.
+func (h *Handle[T]) Get() T {
+	return h.value
+}
+
+// The identity of a heap, shared by the handles pushed to it.
+//
+// Merge forwards the identity of the merged heap to the current heap,
+// so the handles follow it without being visited.
+type owner struct {
+	next *owner // nil if it is the identity of a heap
+}
+
+// Return the identity that o is forwarded to.
+func (o *owner) find() *owner {
+	for ; o.next != nil; o = o.next {
+		//halve the path for the later lookups
+		if o.next.next != nil {
+			o.next = o.next.next
+		}
+	}
+	return o
+}
+
+// A max fibonacci heap.
+//
+// It supports push, merge and increasing key in Θ(1) amortized time, which suits
+// algorithms dominated by key changes such as Dijkstra and Prim.
+//
+// interface: PriorityQueue
+type FibonacciHeap[T any] struct {
+	top *Handle[T]
+	cmp func(T, T) int
+	len int
+	id  *owner // nil until the first handle is pushed
+}
+
+var _ adt.PriorityQueue[int] = &FibonacciHeap[int]{}
+
+func New[T any](predicate func(T, T) int) FibonacciHeap[T] {
+	return FibonacciHeap[T]{cmp: predicate}
+}
+
+// Return the number of element.
+func (f *FibonacciHeap[T]) Len() int {
+	return f.len
+}
+
+// Push e to the heap.
+func (f *FibonacciHeap[T]) Push(e T) {
+	f.push(&Handle[T]{value: e})
+}
+
+// Push e to the heap, return a handle to it.
+func (f *FibonacciHeap[T]) PushHandle(e T) *Handle[T] {
+	if f.id == nil {
+		f.id = &owner{}
+	}
+	n := &Handle[T]{value: e, owner: f.id}
+	f.push(n)
+	return n
+}
+
+// Remove and return the top element from the heap in Θ(log n) amortized time.
+func (f *FibonacciHeap[T]) Pop() T {
+	f.len--
+	top := f.top
+
+	//promote the children to roots
+	if top.child != nil {
+		for c, next := top.child, top.child.right; ; c, next = next, next.right {
+			c.parent = nil
+			c.marked = false
+			if next == top.child {
+				break
+			}
+		}
+		splice(top, top.child)
+		top.child = nil
+	}
+
+	if top.right == top {
+		f.top = nil
+	} else {
+		top.left.right = top.right
+		top.right.left = top.left
+		f.top = top.right
+		f.consolidate()
+	}
+	top.left, top.right = nil, nil
+	return top.value
+}
+
+// Return the top of the heap.
+func (f *FibonacciHeap[T]) Top() T {
+	return f.top.value
+}
+
+// Merge and destruct the heap into the current heap in Θ(1).
+func (f *FibonacciHeap[T]) Merge(heap FibonacciHeap[T]) {
+	if heap.top == nil {
+		return
+	}
+	if f.id == nil {
+		f.id = heap.id
+	} else if heap.id != nil {
+		heap.id.next = f.id
+	}
+	f.len += heap.len
+	if f.top == nil {
+		f.top = heap.top
+		return
+	}
+	splice(f.top, heap.top)
+	if f.cmp(heap.top.value, f.top.value) > 0 {
+		f.top = heap.top
+	}
+}
+
+// Increase the value of the element to e in Θ(1) amortized time.
+//
+// It panics if e is less than the current value.
+func (f *FibonacciHeap[T]) IncreaseKey(handle *Handle[T], e T) {
+	f.validate(handle)
+	if f.cmp(e, handle.value) < 0 {
+		panic("fibonacci_heap: IncreaseKey with a lesser value")
+	}
+	handle.value = e
+	if p := handle.parent; p != nil && f.cmp(handle.value, p.value) > 0 {
+		f.cut(handle)
+	}
+	if f.cmp(handle.value, f.top.value) > 0 {
+		f.top = handle
+	}
+}
+
+// Remove the element from the heap in Θ(log n) amortized time.
+func (f *FibonacciHeap[T]) Delete(handle *Handle[T]) {
+	f.validate(handle)
+	if handle.parent != nil {
+		f.cut(handle)
+	}
+	f.top = handle
+	f.Pop()
+}
+
+// Panic if the handle is popped, deleted or from another heap.
+func (f *FibonacciHeap[T]) validate(handle *Handle[T]) {
+	if handle.left == nil || handle.owner.find() != f.id {
+		panic("fibonacci_heap: handle is not in the heap")
+	}
+	handle.owner = f.id
+}
+
+// Push the single node to the heap.
+func (f *FibonacciHeap[T]) push(n *Handle[T]) {
+	n.left, n.right = n, n
+	f.addRoot(n)
+	f.len++
+}
+
+// Add the single node to the root list.
+func (f *FibonacciHeap[T]) addRoot(n *Handle[T]) {
+	if f.top == nil {
+		f.top = n
+		return
+	}
+	splice(f.top, n)
+	if f.cmp(n.value, f.top.value) > 0 {
+		f.top = n
+	}
+}
+
+// Move the node to the root list, then cut its marked ancestors as well.
+func (f *FibonacciHeap[T]) cut(n *Handle[T]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if n.right == n {
+			p.child = nil
+		} else {
+			n.left.right = n.right
+			n.right.left = n.left
+			if p.child == n {
+				p.child = n.right
+			}
+		}
+		p.degree--
+		n.parent = nil
+		n.marked = false
+		n.left, n.right = n, n
+		splice(f.top, n)
+
+		if !p.marked {
+			p.marked = p.parent != nil
+			break
+		}
+	}
+}
+
+// Link the roots of the same degree until every degree is unique, then find the new top.
+func (f *FibonacciHeap[T]) consolidate() {
+	var degrees [maxDegree]*Handle[T]
+	last := f.top.left
+	for n, next := f.top, f.top.right; ; n, next = next, next.right {
+		done := n == last
+		x := n
+		for y := degrees[x.degree]; y != nil; y = degrees[x.degree] {
+			degrees[x.degree] = nil
+			if f.cmp(y.value, x.value) > 0 {
+				x, y = y, x
+			}
+			f.link(y, x)
+		}
+		degrees[x.degree] = x
+		if done {
+			break
+		}
+	}
+
+	//rebuild the root list
+	f.top = nil
+	for _, n := range degrees {
+		if n != nil {
+			n.left, n.right = n, n
+			f.addRoot(n)
+		}
+	}
+}
+
+// Make the root y a child of the root x.
+func (f *FibonacciHeap[T]) link(y, x *Handle[T]) {
+	y.parent = x
+	y.marked = false
+	y.left, y.right = y, y
+	if x.child == nil {
+		x.child = y
+	} else {
+		splice(x.child, y)
+	}
+	x.degree++
+}
+
+// Concatenate the circular list b after the node a.
+func splice[T any](a, b *Handle[T]) {
+	aNext, bLast := a.right, b.left
+	a.right, b.left = b, a
+	bLast.right, aNext.left = aNext, bLast
+}
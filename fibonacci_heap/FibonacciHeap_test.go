@@ -0,0 +1,332 @@
+package fibonacci_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Check the sibling list starting at first, return the number of nodes in the subtrees.
+func checkSiblings[T any](heap FibonacciHeap[T], first, parent *Handle[T], t *testing.T) int {
+	count, degree := 0, 0
+	for n := first; ; n = n.right {
+		if n.right.left != n {
+			t.Fatalf("sibling list of %v is broken", n.value)
+		}
+		if n.parent != parent {
+			t.Fatalf("parent of %v is wrong", n.value)
+		}
+		if parent != nil && heap.cmp(n.value, parent.value) > 0 {
+			t.Fatalf("Heap property violated at %v and %v", parent.value, n.value)
+		}
+		if parent == nil && heap.cmp(n.value, heap.top.value) > 0 {
+			t.Fatalf("root %v is greater than the top %v", n.value, heap.top.value)
+		}
+		if n.child != nil {
+			count += checkSiblings(heap, n.child, n, t)
+		}
+		count++
+		degree++
+		if n.right == first {
+			break
+		}
+	}
+	if parent != nil && parent.degree != degree {
+		t.Fatalf("degree of %v is %d, want %d", parent.value, parent.degree, degree)
+	}
+	return count
+}
+
+func checkFibonacciHeap[T any](heap FibonacciHeap[T], t *testing.T) {
+	count := 0
+	if heap.top != nil {
+		count = checkSiblings(heap, heap.top, nil, t)
+	}
+	if count != heap.Len() {
+		t.Fatalf("heap has %d nodes, Len() = %d", count, heap.Len())
+	}
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	const testSize = 1 << 9
+	heap := New(func(a, b int) int { return a - b })
+	handles := []*Handle[int]{}
+
+	for i := 0; i < 4*testSize; i++ {
+		switch op := rand.Intn(6); {
+		case op < 2 || heap.Len() == 0:
+			handles = append(handles, heap.PushHandle(rand.Intn(testSize)))
+		case op == 2:
+			j := rand.Intn(len(handles))
+			heap.Delete(handles[j])
+			handles[j] = handles[len(handles)-1]
+			handles = handles[:len(handles)-1]
+		case op == 3:
+			handle := handles[rand.Intn(len(handles))]
+			heap.IncreaseKey(handle, handle.Get()+rand.Intn(testSize))
+		case op == 4:
+			other := New(func(a, b int) int { return a - b })
+			for j := rand.Intn(8); j > 0; j-- {
+				handles = append(handles, other.PushHandle(rand.Intn(testSize)))
+			}
+			heap.Merge(other)
+		default:
+			top := heap.Pop()
+			for j, handle := range handles {
+				if handle.left == nil {
+					if handle.Get() != top {
+						t.Fatalf("Pop() removes %d, want %d", handle.Get(), top)
+					}
+					handles[j] = handles[len(handles)-1]
+					handles = handles[:len(handles)-1]
+					break
+				}
+			}
+		}
+
+		if heap.Len() != len(handles) {
+			t.Fatalf("Len() = %d, want %d", heap.Len(), len(handles))
+		}
+		checkFibonacciHeap(heap, t)
+
+		maxValue := -1
+		for _, handle := range handles {
+			maxValue = max(maxValue, handle.Get())
+		}
+		if heap.Len() > 0 && heap.Top() != maxValue {
+			t.Fatalf("Top() = %d, want %d", heap.Top(), maxValue)
+		}
+	}
+}
+
+func TestFibonacciHeap_Pop(t *testing.T) {
+	heap := New(func(a, b int) int { return a - b })
+	for _, i := range rand.Perm(1000) {
+		heap.Push(i)
+	}
+	for i := 999; i >= 0; i-- {
+		if top := heap.Pop(); top != i {
+			t.Fatalf("Pop() = %d, want %d", top, i)
+		}
+	}
+	if heap.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", heap.Len())
+	}
+}
+
+func TestFibonacciHeap_Merge(t *testing.T) {
+	heap1 := New(func(a, b int) int { return a - b })
+	heap2 := New(func(a, b int) int { return a - b })
+	heap1.Merge(heap2)
+	if heap1.Len() != 0 {
+		t.Errorf("Merge() of empty heaps, Len() = %d, want 0", heap1.Len())
+	}
+
+	for i := -100; i < 0; i++ {
+		heap1.Push(i)
+	}
+	for i := 0; i <= 100; i++ {
+		heap2.Push(i)
+	}
+	heap1.Merge(heap2)
+	if heap1.Len() != 201 {
+		t.Errorf("Merge(), Len() = %d, want 201", heap1.Len())
+	}
+	for i := 100; i >= -100; i-- {
+		if top := heap1.Pop(); top != i {
+			t.Fatalf("Pop() = %d, want %d", top, i)
+		}
+	}
+}
+
+func TestFibonacciHeap_InvalidHandle(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Delete() with a deleted handle does not panic")
+		}
+	}()
+
+	heap := New(func(a, b int) int { return a - b })
+	handle := heap.PushHandle(1)
+	heap.Push(2)
+	heap.Delete(handle)
+	heap.Delete(handle)
+}
+
+func TestFibonacciHeap_ForeignHandle(t *testing.T) {
+	a := New(func(a, b int) int { return a - b })
+	b := New(func(a, b int) int { return a - b })
+	handles := []*Handle[int]{}
+	for i := 0; i < 8; i++ {
+		handles = append(handles, a.PushHandle(i))
+		b.Push(i)
+	}
+	a.Pop() //consolidate the trees, so the handles are not all roots
+
+	tests := []struct {
+		name string
+		call func(*Handle[int])
+	}{
+		{"Delete", func(h *Handle[int]) { b.Delete(h) }},
+		{"IncreaseKey", func(h *Handle[int]) { b.IncreaseKey(h, 100) }},
+	}
+	for _, test := range tests {
+		for _, handle := range handles[:7] {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("%s() with a handle of another heap does not panic", test.name)
+					}
+				}()
+				test.call(handle)
+			}()
+		}
+	}
+	if a.Len() != 7 || b.Len() != 8 {
+		t.Fatalf("Len() = (%d, %d), want (7, 8)", a.Len(), b.Len())
+	}
+	checkFibonacciHeap(a, t)
+	checkFibonacciHeap(b, t)
+
+	//the handles move with the merged heap
+	c := New(func(a, b int) int { return a - b })
+	c.Merge(b)
+	c.Merge(a)
+	for _, handle := range handles[:7] {
+		c.Delete(handle)
+		checkFibonacciHeap(c, t)
+	}
+	if c.Len() != 8 {
+		t.Fatalf("Len() = %d, want 8", c.Len())
+	}
+}
+
+func TestFibonacciHeap_IncreaseKeyLesser(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("IncreaseKey() with a lesser value does not panic")
+		}
+	}()
+
+	heap := New(func(a, b int) int { return a - b })
+	handle := heap.PushHandle(5)
+	heap.IncreaseKey(handle, 4)
+}
+
+// BenchmarkFibonacciHeap_Push_Small 	10303765	       122.8 ns/op	      64 B/op	       1 allocs/op
+func BenchmarkFibonacciHeap_Push_Small(b *testing.B) {
+	heap := New(func(a, b int64) int { return int(a - b) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+}
+
+// BenchmarkFibonacciHeap_Push_Big   	 6275678	       289.7 ns/op	     224 B/op	       1 allocs/op
+func BenchmarkFibonacciHeap_Push_Big(b *testing.B) {
+	type Large struct {
+		a int64
+		b [20]int64
+	}
+	heap := New(func(l, r Large) int { return int(l.a - r.a) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Push(Large{a: int64(i % (b.N/100 + 1))})
+	}
+}
+
+// BenchmarkFibonacciHeap_Pop_Small  	 3077950	       491.2 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkFibonacciHeap_Pop_Small(b *testing.B) {
+	heap := New(func(a, b int64) int { return int(a - b) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Pop()
+	}
+}
+
+// BenchmarkFibonacciHeap_Pop_Big    	 1797572	       594.1 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkFibonacciHeap_Pop_Big(b *testing.B) {
+	type Large struct {
+		a int64
+		b [20]int64
+	}
+	heap := New(func(l, r *Large) int { return int(l.a - r.a) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(&Large{a: int64(i % (b.N/100 + 1))})
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Pop()
+	}
+}
+
+func ExampleFibonacciHeap_Push() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	heap.Push(3)
+	heap.Push(7)
+	fmt.Println(heap.Len())
+	fmt.Println(heap.Top())
+	// Output:
+	// 3
+	// 7
+}
+
+func ExampleFibonacciHeap_Pop() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	heap.Push(3)
+	heap.Push(7)
+	fmt.Println(heap.Pop())
+	fmt.Println(heap.Pop())
+	// Output:
+	// 7
+	// 5
+}
+
+func ExampleFibonacciHeap_Merge() {
+	heap1 := New(func(a, b int) int { return a - b })
+	heap1.Push(5)
+	heap1.Push(3)
+
+	heap2 := New(func(a, b int) int { return a - b })
+	heap2.Push(10)
+	heap2.Push(8)
+
+	heap1.Merge(heap2)
+	fmt.Println(heap1.Len())
+	fmt.Println(heap1.Top())
+	// Output:
+	// 4
+	// 10
+}
+
+func ExampleFibonacciHeap_IncreaseKey() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	handle := heap.PushHandle(3)
+	heap.Push(7)
+	heap.IncreaseKey(handle, 10)
+	fmt.Println(heap.Top())
+	// Output: 10
+}
+
+func ExampleFibonacciHeap_Delete() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	heap.Push(3)
+	handle := heap.PushHandle(7)
+	heap.Delete(handle)
+	fmt.Println(heap.Len())
+	fmt.Println(heap.Top())
+	// Output:
+	// 2
+	// 5
+}
@@ -0,0 +1,138 @@
+package heap_benchmark
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/evanhyd/sgl/binary_heap"
+	"github.com/evanhyd/sgl/binomial_heap"
+	"github.com/evanhyd/sgl/fibonacci_heap"
+	"github.com/evanhyd/sgl/pairing_heap"
+)
+
+// BenchmarkFibonacciHeap_Merge    	 4009502	       262.2 ns/op	      64 B/op	       1 allocs/op
+func BenchmarkFibonacciHeap_Merge(b *testing.B) {
+	heap := fibonacci_heap.New(func(a, b int64) int { return int(a - b) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		other := fibonacci_heap.New(func(a, b int64) int { return int(a - b) })
+		other.Push(int64(i))
+		heap.Merge(other)
+	}
+}
+
+// BenchmarkBinomialHeap_Merge     	 4890570	       227.9 ns/op	      56 B/op	       2 allocs/op
+func BenchmarkBinomialHeap_Merge(b *testing.B) {
+	heap := binomial_heap.New(func(a, b int64) int { return int(a - b) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		other := binomial_heap.New(func(a, b int64) int { return int(a - b) })
+		other.Push(int64(i))
+		heap.Merge(other)
+	}
+}
+
+// BenchmarkPairingHeap_Merge      	 6582462	       157.2 ns/op	      48 B/op	       1 allocs/op
+func BenchmarkPairingHeap_Merge(b *testing.B) {
+	heap := pairing_heap.New(func(a, b int64) int { return int(a - b) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		other := pairing_heap.New(func(a, b int64) int { return int(a - b) })
+		other.Push(int64(i))
+		heap.Merge(other)
+	}
+}
+
+// The operations of a heap under the Dijkstra-like workload.
+//
+// The keys hold the priority in the high 32 bits and the node in the low 32 bits.
+type dijkstraHeap[H any] struct {
+	push     func(int64) H
+	increase func(H, int64) // increase the key by the delta
+	pop      func() int64
+	len      func() int
+}
+
+// Run a Dijkstra-like workload: pop the top, then increase the keys of some random nodes.
+func benchmarkDijkstra[H any](b *testing.B, newHeap func() dijkstraHeap[H]) {
+	const (
+		size      = 1 << 12
+		neighbour = 16
+	)
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		heap := newHeap()
+		handles := make([]H, size)
+		popped := make([]bool, size)
+		b.StartTimer()
+
+		for j := range handles {
+			handles[j] = heap.push(int64(j))
+		}
+		for heap.len() > neighbour {
+			popped[heap.pop()&(1<<32-1)] = true
+			for k := 0; k < neighbour; k++ {
+				if j := r.Intn(size); !popped[j] {
+					heap.increase(handles[j], r.Int63n(size)<<32)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkFibonacciHeap_Dijkstra 	     207	   6273555 ns/op	  262177 B/op	    4097 allocs/op
+func BenchmarkFibonacciHeap_Dijkstra(b *testing.B) {
+	benchmarkDijkstra(b, func() dijkstraHeap[*fibonacci_heap.Handle[int64]] {
+		heap := fibonacci_heap.New(func(a, b int64) int { return int(a - b) })
+		return dijkstraHeap[*fibonacci_heap.Handle[int64]]{
+			heap.PushHandle,
+			func(h *fibonacci_heap.Handle[int64], delta int64) { heap.IncreaseKey(h, h.Get()+delta) },
+			heap.Pop,
+			heap.Len,
+		}
+	})
+}
+
+// BenchmarkBinomialHeap_Dijkstra  	     160	   7494268 ns/op	  230177 B/op	    8205 allocs/op
+func BenchmarkBinomialHeap_Dijkstra(b *testing.B) {
+	benchmarkDijkstra(b, func() dijkstraHeap[*binomial_heap.Handle[int64]] {
+		heap := binomial_heap.New(func(a, b int64) int { return int(a - b) })
+		return dijkstraHeap[*binomial_heap.Handle[int64]]{
+			heap.PushHandle,
+			func(h *binomial_heap.Handle[int64], delta int64) { heap.IncreaseKey(h, h.Get()+delta) },
+			heap.Pop,
+			heap.Len,
+		}
+	})
+}
+
+// BenchmarkIndexedHeap_Dijkstra   	     222	   5063942 ns/op	  220304 B/op	    4112 allocs/op
+func BenchmarkIndexedHeap_Dijkstra(b *testing.B) {
+	benchmarkDijkstra(b, func() dijkstraHeap[*binary_heap.Handle[int64]] {
+		heap := binary_heap.NewIndexed(func(a, b int64) int { return int(a - b) })
+		return dijkstraHeap[*binary_heap.Handle[int64]]{
+			heap.PushHandle,
+			func(h *binary_heap.Handle[int64], delta int64) { heap.Update(h, h.Get()+delta) },
+			heap.Pop,
+			heap.Len,
+		}
+	})
+}
+
+// BenchmarkPairingHeap_Dijkstra   	     224	   4792858 ns/op	  196640 B/op	    4097 allocs/op
+func BenchmarkPairingHeap_Dijkstra(b *testing.B) {
+	benchmarkDijkstra(b, func() dijkstraHeap[*pairing_heap.Handle[int64]] {
+		heap := pairing_heap.New(func(a, b int64) int { return int(a - b) })
+		return dijkstraHeap[*pairing_heap.Handle[int64]]{
+			heap.PushHandle,
+			func(h *pairing_heap.Handle[int64], delta int64) { heap.IncreaseKey(h, h.Get()+delta) },
+			heap.Pop,
+			heap.Len,
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package pairing_heap
+
+import "github.com/evanhyd/sgl/adt"
+
+// A handle to an element in a PairingHeap.
+//
+// It is the node of the heap, it links to the first child and the next sibling.
+// prev points to the previous sibling, or the parent if it is the first child.
+// It stays valid until the element is popped or deleted.
+type Handle[T any] struct {
+	value T
+	child *Handle[T]
+	next  *Handle[T]
+	prev  *Handle[T]
+	owner *owner
+}
+
+// Return the value.
+func (h *Handle[T]) Get() T {
+	return h.value
+}
+
+// The identity of a heap, shared by the handles pushed to it.
+//
+// Merge forwards the identity of the merged heap to the current heap,
+// so the handles follow it without being visited.
+type owner struct {
+	next *owner // nil if it is the identity of a heap
+}
+
+// Return the identity that o is forwarded to.
+func (o *owner) find() *owner {
+	for ; o.next != nil; o = o.next {
+		//halve the path for the later lookups
+		if o.next.next != nil {
+			o.next = o.next.next
+		}
+	}
+	return o
+}
+
+// A max pairing heap.
+//
+// It is simpler and often faster than FibonacciHeap in practice,
+// with constant push, merge and fast increasing key.
+//
+// interface: PriorityQueue
+type PairingHeap[T any] struct {
+	root *Handle[T]
+	cmp  func(T, T) int
+	len  int
+	id   *owner // nil until the first handle is pushed
+}
+
+var _ adt.PriorityQueue[int] = &PairingHeap[int]{}
+
+func New[T any](predicate func(T, T) int) PairingHeap[T] {
+	return PairingHeap[T]{cmp: predicate}
+}
+
+// Return the number of element.
+func (p *PairingHeap[T]) Len() int {
+	return p.len
+}
+
+// Push e to the heap.
+func (p *PairingHeap[T]) Push(e T) {
+	p.root = p.meld(p.root, &Handle[T]{value: e})
+	p.len++
+}
+
+// Push e to the heap, return a handle to it.
+func (p *PairingHeap[T]) PushHandle(e T) *Handle[T] {
+	if p.id == nil {
+		p.id = &owner{}
+	}
+	n := &Handle[T]{value: e, owner: p.id}
+	p.root = p.meld(p.root, n)
+	p.len++
+	return n
+}
+
+// Remove and return the top element from the heap in Θ(log n) amortized time.
+func (p *PairingHeap[T]) Pop() T {
+	top := p.root
+	p.root = p.mergePairs(top.child)
+	p.len--
+	p.invalidate(top)
+	return top.value
+}
+
+// Return the top of the heap.
+func (p *PairingHeap[T]) Top() T {
+	return p.root.value
+}
+
+// Merge and destruct the heap into the current heap in Θ(1).
+func (p *PairingHeap[T]) Merge(heap PairingHeap[T]) {
+	if p.id == nil {
+		p.id = heap.id
+	} else if heap.id != nil {
+		heap.id.next = p.id
+	}
+	p.root = p.meld(p.root, heap.root)
+	p.len += heap.len
+}
+
+// Increase the value of the element to e in O(log n) amortized time.
+//
+// It is the decrease-key operation of a max-heap.
+// It panics if e is less than the current value.
+func (p *PairingHeap[T]) IncreaseKey(handle *Handle[T], e T) {
+	p.validate(handle)
+	if p.cmp(e, handle.value) < 0 {
+		panic("pairing_heap: IncreaseKey with a lesser value")
+	}
+	handle.value = e
+	if handle != p.root {
+		p.detach(handle)
+		p.root = p.meld(p.root, handle)
+	}
+}
+
+// Remove the element from the heap in Θ(log n) amortized time.
+func (p *PairingHeap[T]) Delete(handle *Handle[T]) {
+	p.validate(handle)
+	if handle == p.root {
+		p.Pop()
+		return
+	}
+	p.detach(handle)
+	p.root = p.meld(p.root, p.mergePairs(handle.child))
+	p.len--
+	p.invalidate(handle)
+}
+
+// Panic if the handle is popped, deleted or from another heap.
+func (p *PairingHeap[T]) validate(handle *Handle[T]) {
+	if handle.prev == handle || handle.owner.find() != p.id {
+		panic("pairing_heap: handle is not in the heap")
+	}
+	handle.owner = p.id
+}
+
+// Mark the removed node by pointing prev to itself.
+func (p *PairingHeap[T]) invalidate(n *Handle[T]) {
+	n.child, n.next, n.prev = nil, nil, n
+}
+
+// Unlink the subtree rooted at n from its parent.
+func (p *PairingHeap[T]) detach(n *Handle[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	n.next, n.prev = nil, nil
+}
+
+// Meld two trees, return the new root.
+//
+// Both roots must have no sibling.
+func (p *PairingHeap[T]) meld(a, b *Handle[T]) *Handle[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if p.cmp(b.value, a.value) > 0 {
+		a, b = b, a
+	}
+	b.prev, b.next = a, a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// Meld the sibling list starting at first into one tree in two passes, return the new root.
+func (p *PairingHeap[T]) mergePairs(first *Handle[T]) *Handle[T] {
+	//meld the pairs from left to right, chain the results in reverse order
+	var pairs *Handle[T]
+	for first != nil {
+		a, b := first, first.next
+		first = nil
+		if b != nil {
+			first = b.next
+			b.next, b.prev = nil, nil
+		}
+		a.next, a.prev = nil, nil
+		a = p.meld(a, b)
+		a.next = pairs
+		pairs = a
+	}
+
+	//meld the results from right to left
+	var root *Handle[T]
+	for pairs != nil {
+		next := pairs.next
+		pairs.next = nil
+		root = p.meld(root, pairs)
+		pairs = next
+	}
+	return root
+}
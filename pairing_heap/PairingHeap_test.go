@@ -0,0 +1,233 @@
+package pairing_heap
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Check the sibling list starting at first, return the number of nodes in the subtrees.
+func checkSiblings[T any](heap PairingHeap[T], first, parent *Handle[T], t *testing.T) int {
+	count := 0
+	prev := parent
+	for n := first; n != nil; n = n.next {
+		if n.prev != prev {
+			t.Fatalf("prev of %v is wrong", n.value)
+		}
+		if heap.cmp(n.value, parent.value) > 0 {
+			t.Fatalf("Heap property violated at %v and %v", parent.value, n.value)
+		}
+		count += checkSiblings(heap, n.child, n, t) + 1
+		prev = n
+	}
+	return count
+}
+
+func checkPairingHeap[T any](heap PairingHeap[T], t *testing.T) {
+	count := 0
+	if root := heap.root; root != nil {
+		if root.prev != nil || root.next != nil {
+			t.Fatalf("root %v has parent or sibling", root.value)
+		}
+		count = checkSiblings(heap, root.child, root, t) + 1
+	}
+	if count != heap.Len() {
+		t.Fatalf("heap has %d nodes, Len() = %d", count, heap.Len())
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	const testSize = 1 << 9
+	heap := New(func(a, b int) int { return a - b })
+	values := map[*Handle[int]]int{}
+	for i := 0; i < testSize; i++ {
+		handle := heap.PushHandle(rand.Intn(testSize))
+		values[handle] = handle.Get()
+	}
+	checkPairingHeap(heap, t)
+
+	//visit every handle once to increase it, delete it, or pop the top instead
+	for handle := range values {
+		switch rand.Intn(3) {
+		case 0:
+			heap.Delete(handle)
+			delete(values, handle)
+		case 1:
+			heap.IncreaseKey(handle, handle.Get()+rand.Intn(testSize))
+			values[handle] = handle.Get()
+		default:
+			heap.Pop()
+			checkPairingHeap(heap, t)
+			for h := range values {
+				if h.prev == h {
+					delete(values, h)
+				}
+			}
+		}
+		checkPairingHeap(heap, t)
+	}
+
+	other := New(func(a, b int) int { return a - b })
+	for i := 0; i < testSize; i++ {
+		handle := other.PushHandle(rand.Intn(testSize))
+		values[handle] = handle.Get()
+	}
+	heap.Merge(other)
+	checkPairingHeap(heap, t)
+
+	//drain the heap, the pops follow the values in descending order
+	expected := []int{}
+	for _, value := range values {
+		expected = append(expected, value)
+	}
+	slices.Sort(expected)
+	slices.Reverse(expected)
+	for i, value := range expected {
+		if top := heap.Pop(); top != value {
+			t.Fatalf("Pop() #%d = %d, want %d", i, top, value)
+		}
+	}
+	if heap.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", heap.Len())
+	}
+	for handle := range values {
+		if handle.prev != handle {
+			t.Fatalf("handle of %d is still in the drained heap", handle.Get())
+		}
+	}
+}
+
+func TestPairingHeap_Root(t *testing.T) {
+	heap := New(func(a, b int) int { return a - b })
+	root := heap.PushHandle(10)
+	for i := 0; i < 8; i++ {
+		heap.Push(i)
+	}
+
+	//increasing the root keeps it in place
+	heap.IncreaseKey(root, 20)
+	if heap.root != root || heap.Top() != 20 {
+		t.Fatalf("IncreaseKey() of the root, Top() = %d, want 20", heap.Top())
+	}
+	checkPairingHeap(heap, t)
+
+	heap.Delete(root)
+	if heap.Top() != 7 || heap.Len() != 8 {
+		t.Fatalf("Delete() of the root, (Top(), Len()) = (%d, %d), want (7, 8)", heap.Top(), heap.Len())
+	}
+	checkPairingHeap(heap, t)
+}
+
+func TestPairingHeap_Panic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(heap *PairingHeap[int], handle *Handle[int])
+	}{
+		{"IncreaseKey() with a lesser value", func(heap *PairingHeap[int], handle *Handle[int]) { heap.IncreaseKey(handle, 0) }},
+		{"Delete() with a deleted handle", func(heap *PairingHeap[int], handle *Handle[int]) { heap.Delete(handle); heap.Delete(handle) }},
+		{"IncreaseKey() with a popped handle", func(heap *PairingHeap[int], handle *Handle[int]) { heap.Pop(); heap.IncreaseKey(handle, 10) }},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s does not panic", test.name)
+				}
+			}()
+
+			heap := New(func(a, b int) int { return a - b })
+			handle := heap.PushHandle(5)
+			heap.Push(1)
+			test.f(&heap, handle)
+		}()
+	}
+}
+
+func TestPairingHeap_ForeignHandle(t *testing.T) {
+	a := New(func(a, b int) int { return a - b })
+	b := New(func(a, b int) int { return a - b })
+	handles := []*Handle[int]{}
+	for i := 0; i < 8; i++ {
+		handles = append(handles, a.PushHandle(i))
+		b.Push(i)
+	}
+	a.Pop() //pair the children, so the handles are not all children of the root
+
+	tests := []struct {
+		name string
+		call func(*Handle[int])
+	}{
+		{"Delete", func(h *Handle[int]) { b.Delete(h) }},
+		{"IncreaseKey", func(h *Handle[int]) { b.IncreaseKey(h, 100) }},
+	}
+	for _, test := range tests {
+		for _, handle := range handles[:7] {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("%s() with a handle of another heap does not panic", test.name)
+					}
+				}()
+				test.call(handle)
+			}()
+		}
+	}
+	if a.Len() != 7 || b.Len() != 8 {
+		t.Fatalf("Len() = (%d, %d), want (7, 8)", a.Len(), b.Len())
+	}
+	checkPairingHeap(a, t)
+	checkPairingHeap(b, t)
+
+	//the handles move with the merged heap
+	c := New(func(a, b int) int { return a - b })
+	c.Merge(b)
+	c.Merge(a)
+	for _, handle := range handles[:7] {
+		c.Delete(handle)
+		checkPairingHeap(c, t)
+	}
+	if c.Len() != 8 {
+		t.Fatalf("Len() = %d, want 8", c.Len())
+	}
+}
+
+// BenchmarkPairingHeap_Push_Small 	 7702827	       172.4 ns/op	      48 B/op	       1 allocs/op
+func BenchmarkPairingHeap_Push_Small(b *testing.B) {
+	heap := New(func(a, b int64) int { return int(a - b) })
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+}
+
+// BenchmarkPairingHeap_Pop_Small  	 5102037	       315.9 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkPairingHeap_Pop_Small(b *testing.B) {
+	heap := New(func(a, b int64) int { return int(a - b) })
+	for i := 0; i < b.N; i++ {
+		heap.Push(int64(i % (b.N/100 + 1)))
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		heap.Pop()
+	}
+}
+
+func ExamplePairingHeap() {
+	heap := New(func(a, b int) int { return a - b })
+	heap.Push(5)
+	low := heap.PushHandle(1)
+	mid := heap.PushHandle(3)
+
+	heap.IncreaseKey(low, 9)
+	heap.Delete(mid)
+	for heap.Len() > 0 {
+		fmt.Println(heap.Pop())
+	}
+	// Output:
+	// 9
+	// 5
+}